@@ -0,0 +1,74 @@
+package openapi_validator
+
+import (
+	"regexp"
+	"strings"
+)
+
+// AdapterOption is a function type used to configure the middleware of the router adapters
+// (the gin, echo and chi subpackages).
+type AdapterOption func(*AdapterOptions)
+
+// AdapterOptions holds the configuration shared by the router adapters.
+type AdapterOptions struct {
+	// PathPrefix is stripped from route paths before they are looked up in the spec.
+	PathPrefix string
+}
+
+// NewAdapterOptions returns the AdapterOptions configured by opts.
+func NewAdapterOptions(opts ...AdapterOption) *AdapterOptions {
+	o := &AdapterOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithPathPrefix returns an AdapterOption that strips prefix from route paths before looking
+// them up in the spec, for route groups mounted below the paths the spec declares (e.g. "/api/v1").
+func WithPathPrefix(prefix string) AdapterOption {
+	return func(o *AdapterOptions) {
+		o.PathPrefix = strings.TrimSuffix(prefix, "/")
+	}
+}
+
+// OperationPath returns the OpenAPI path template of a route path matched by the adapter's
+// router, with PathPrefix stripped (see OpenAPIPath).
+func (o *AdapterOptions) OperationPath(routePath string) string {
+	return OpenAPIPath(strings.TrimPrefix(routePath, o.PathPrefix))
+}
+
+// routeParamPattern matches a route parameter with a regular expression, such as Chi's {id:[0-9]+}.
+var routeParamPattern = regexp.MustCompile(`\{([^}:]+):[^}]*\}`)
+
+// OpenAPIPath converts a Gin, Echo or Chi route path to the OpenAPI path template it stands
+// for: /users/:id/*file (Gin), /users/:id/* (Echo) and /users/{id:[0-9]+}/* (Chi) become
+// /users/{id}/{file}, /users/{id}/{*} and /users/{id}/{*}.
+func OpenAPIPath(routePath string) string {
+	routePath = routeParamPattern.ReplaceAllString(routePath, "{$1}")
+	segments := strings.Split(routePath, "/")
+	for i, segment := range segments {
+		switch {
+		case segment == "*":
+			segments[i] = "{*}"
+		case strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*"):
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// PathParamsFor maps path parameter values, in the order the router captured them, to the
+// parameter names of the OpenAPI path template, which may be named differently.
+func PathParamsFor(template string, values []string) map[string]string {
+	pathParams := make(map[string]string, len(values))
+	i := 0
+	for _, segment := range strings.Split(template, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") || i >= len(values) {
+			continue
+		}
+		pathParams[segment[1:len(segment)-1]] = values[i]
+		i++
+	}
+	return pathParams
+}
@@ -0,0 +1,56 @@
+package openapi_validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOpenAPIPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		routePath string
+		want      string
+	}{
+		{name: "Literal Path", routePath: "/users", want: "/users"},
+		{name: "Gin Parameters", routePath: "/users/:id/*file", want: "/users/{id}/{file}"},
+		{name: "Echo Catch-All", routePath: "/users/:id/*", want: "/users/{id}/{*}"},
+		{name: "Chi Regular Expression", routePath: "/users/{id:[0-9]+}/*", want: "/users/{id}/{*}"},
+		{name: "Chi Parameter", routePath: "/users/{id}", want: "/users/{id}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := OpenAPIPath(tt.routePath)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPathParamsFor(t *testing.T) {
+	// Act
+	got := PathParamsFor("/users/{userId}/files/{name}", []string{"7", "a.txt", "extra"})
+
+	// Assert
+	want := map[string]string{"userId": "7", "name": "a.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestAdapterOptions(t *testing.T) {
+	// Arrange
+	opts := NewAdapterOptions(WithPathPrefix("/api/v1/"))
+
+	// Act
+	got := opts.OperationPath("/api/v1/users/:id")
+
+	// Assert
+	if opts.PathPrefix != "/api/v1" || got != "/users/{id}" {
+		t.Errorf("expected the prefix to be stripped, got %q and %s", opts.PathPrefix, got)
+	}
+}
@@ -0,0 +1,182 @@
+package openapi_validator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// Authenticator is a function type used to authenticate a request against a single security scheme.
+// It returns the authenticated principal (e.g. a user or token claims), which is placed on the
+// request context, or an error if the credentials are missing or invalid. Wrap ErrForbidden to
+// report valid credentials lacking the required scopes.
+type Authenticator func(ctx context.Context, input *AuthenticationInput) (principal any, err error)
+
+// AuthenticationInput describes the security scheme a request must satisfy.
+type AuthenticationInput struct {
+	// Request is the incoming request. Its body may be read; it is restored afterwards.
+	Request *http.Request
+	// SchemeName is the name of the security scheme in components.securitySchemes.
+	SchemeName string
+	// Scheme is the security scheme definition.
+	Scheme *openapi3.SecurityScheme
+	// Scopes are the scopes required by the security requirement (oauth2 and openIdConnect).
+	Scopes []string
+}
+
+// Credential extracts the raw credential for the scheme from the request:
+// the API key for apiKey schemes, and the token following the authorization scheme
+// (e.g. the bearer token) for http, oauth2 and openIdConnect schemes.
+// For http basic, use Request.BasicAuth instead.
+func (in *AuthenticationInput) Credential() (string, bool) {
+	if in.Scheme == nil {
+		return "", false
+	}
+
+	switch in.Scheme.Type {
+	case "apiKey":
+		switch in.Scheme.In {
+		case "header":
+			value := in.Request.Header.Get(in.Scheme.Name)
+			return value, value != ""
+		case "query":
+			value := in.Request.URL.Query().Get(in.Scheme.Name)
+			return value, value != ""
+		case "cookie":
+			cookie, err := in.Request.Cookie(in.Scheme.Name)
+			if err != nil {
+				return "", false
+			}
+			return cookie.Value, cookie.Value != ""
+		}
+		return "", false
+	case "http":
+		return authorizationToken(in.Request, in.Scheme.Scheme)
+	case "oauth2", "openIdConnect":
+		return authorizationToken(in.Request, "bearer")
+	}
+	return "", false
+}
+
+// authorizationToken returns the credentials of the Authorization header if it uses scheme.
+func authorizationToken(r *http.Request, scheme string) (string, bool) {
+	prefix, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(prefix, scheme) || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// securitySchemeError is the failure of a single security scheme within a requirement.
+type securitySchemeError struct {
+	scheme string
+	err    error
+}
+
+func (e *securitySchemeError) Error() string {
+	return fmt.Sprintf("security scheme %q: %v", e.scheme, e.err)
+}
+
+func (e *securitySchemeError) Unwrap() error {
+	return e.err
+}
+
+// authenticate evaluates the security requirements of route using the registered authenticators.
+// Requirements are alternatives: the first one whose schemes all authenticate wins, and the
+// principals it produced are returned keyed by scheme name. If none is satisfied, an
+// *openapi3filter.SecurityRequirementsError listing every alternative's failure is returned.
+func (v *Validator) authenticate(r *http.Request, route *routers.Route) (map[string]any, error) {
+	security := route.Operation.Security
+	if security == nil {
+		security = &route.Spec.Security
+	}
+	if len(*security) == 0 {
+		return nil, nil
+	}
+
+	var schemes openapi3.SecuritySchemes
+	if route.Spec.Components != nil {
+		schemes = route.Spec.Components.SecuritySchemes
+	}
+
+	body, err := bufferBody(r)
+	if err != nil {
+		return nil, &openapi3filter.RequestError{Reason: "reading failed", Err: err}
+	}
+	defer restoreBody(r, body)
+
+	var errs []error
+	for _, requirement := range *security {
+		principals, err := v.authenticateRequirement(r, requirement, schemes, body)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return principals, nil
+	}
+
+	return nil, &openapi3filter.SecurityRequirementsError{
+		SecurityRequirements: *security,
+		Errors:               errs,
+	}
+}
+
+// authenticateRequirement authenticates every scheme of a single security requirement.
+// An empty requirement allows anonymous access.
+func (v *Validator) authenticateRequirement(r *http.Request, requirement openapi3.SecurityRequirement, schemes openapi3.SecuritySchemes, body []byte) (map[string]any, error) {
+	principals := make(map[string]any, len(requirement))
+	for _, name := range mapkeys.Sorted(requirement) {
+		ref := schemes[name]
+		if ref == nil || ref.Value == nil {
+			return nil, &securitySchemeError{scheme: name, err: errors.New("security scheme is not declared")}
+		}
+
+		authenticator := v.Options.Authenticators[name]
+		if authenticator == nil {
+			return nil, &securitySchemeError{scheme: name, err: errors.New("no authenticator registered")}
+		}
+
+		restoreBody(r, body)
+		principal, err := authenticator(r.Context(), &AuthenticationInput{
+			Request:    r,
+			SchemeName: name,
+			Scheme:     ref.Value,
+			Scopes:     requirement[name],
+		})
+		if err != nil {
+			return nil, &securitySchemeError{scheme: name, err: err}
+		}
+		principals[name] = principal
+	}
+	return principals, nil
+}
+
+// bufferBody reads the request body into memory so it can be consumed more than once.
+func bufferBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	defer r.Body.Close()
+	return io.ReadAll(r.Body)
+}
+
+// restoreBody replaces the request body with a fresh reader over body.
+func restoreBody(r *http.Request, body []byte) {
+	if body == nil {
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+}
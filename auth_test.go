@@ -0,0 +1,176 @@
+package openapi_validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const testAuthSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, in: header, name: X-API-Key}
+    bearer: {type: http, scheme: bearer}
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes: {write: write access}
+security:
+  - apiKey: []
+  - bearer: []
+paths:
+  /public:
+    get:
+      security: []
+      responses:
+        '200':
+          description: OK
+  /either:
+    get:
+      responses:
+        '200':
+          description: OK
+  /both:
+    get:
+      security:
+        - apiKey: []
+          bearer: []
+      responses:
+        '200':
+          description: OK
+  /scoped:
+    post:
+      security:
+        - oauth: [write]
+      responses:
+        '200':
+          description: OK
+`
+
+func TestValidator_Middleware_Authentication(t *testing.T) {
+	v, err := NewFromBytes([]byte(testAuthSpec),
+		WithAuthenticator("apiKey", func(ctx context.Context, input *AuthenticationInput) (any, error) {
+			key, ok := input.Credential()
+			if !ok || key != "secret" {
+				return nil, errors.New("invalid api key")
+			}
+			return "key-user", nil
+		}),
+		WithAuthenticator("bearer", func(ctx context.Context, input *AuthenticationInput) (any, error) {
+			token, ok := input.Credential()
+			if !ok || token != "token" {
+				return nil, errors.New("invalid bearer token")
+			}
+			return "token-user", nil
+		}),
+		WithAuthenticator("oauth", func(ctx context.Context, input *AuthenticationInput) (any, error) {
+			token, ok := input.Credential()
+			if !ok {
+				return nil, errors.New("missing token")
+			}
+			if token != "writer" || !slices.Contains(input.Scopes, "write") {
+				return nil, fmt.Errorf("scope write not granted: %w", ErrForbidden)
+			}
+			return "writer", nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	var principal any
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		headers       map[string]string
+		wantStatus    int
+		wantPrincipal any
+	}{
+		{"Anonymous Operation", "GET", "/public", nil, http.StatusOK, nil},
+		{"First Alternative", "GET", "/either", map[string]string{"X-API-Key": "secret"}, http.StatusOK, "key-user"},
+		{"Second Alternative", "GET", "/either", map[string]string{"Authorization": "Bearer token"}, http.StatusOK, "token-user"},
+		{"No Alternative Satisfied", "GET", "/either", nil, http.StatusUnauthorized, nil},
+		{"Combined Requirement Satisfied", "GET", "/both", map[string]string{"X-API-Key": "secret", "Authorization": "Bearer token"}, http.StatusOK, "key-user"},
+		{"Combined Requirement Partially Satisfied", "GET", "/both", map[string]string{"X-API-Key": "secret"}, http.StatusUnauthorized, nil},
+		{"Insufficient Scope", "POST", "/scoped", map[string]string{"Authorization": "Bearer reader"}, http.StatusForbidden, nil},
+		{"Sufficient Scope", "POST", "/scoped", map[string]string{"Authorization": "Bearer writer"}, http.StatusOK, "writer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			principal = nil
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, val := range tt.headers {
+				req.Header.Set(k, val)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(w, req)
+
+			// Assert
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			if principal != tt.wantPrincipal {
+				t.Errorf("expected principal %v, got %v", tt.wantPrincipal, principal)
+			}
+		})
+	}
+}
+
+func TestAuthenticationInput_Credential(t *testing.T) {
+	t.Run("API Key In Query", func(t *testing.T) {
+		// Arrange
+		input := &AuthenticationInput{
+			Request: httptest.NewRequest("GET", "/?key=abc", nil),
+			Scheme:  &openapi3.SecurityScheme{Type: "apiKey", In: "query", Name: "key"},
+		}
+
+		// Act
+		got, ok := input.Credential()
+
+		// Assert
+		if !ok || got != "abc" {
+			t.Errorf("expected credential abc, got %q", got)
+		}
+	})
+
+	t.Run("Wrong Authorization Scheme", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/", nil)
+		req.SetBasicAuth("user", "pass")
+		input := &AuthenticationInput{
+			Request: req,
+			Scheme:  &openapi3.SecurityScheme{Type: "http", Scheme: "bearer"},
+		}
+
+		// Act
+		_, ok := input.Credential()
+
+		// Assert
+		if ok {
+			t.Error("expected no bearer credential for a basic Authorization header")
+		}
+	})
+}
@@ -0,0 +1,102 @@
+// Package chi adapts the OpenAPI validator to Chi. Operations are matched by the Chi route
+// pattern (RoutePattern()), so the middleware works at any level of a router tree, including
+// Mux.Use before routing has completed and inside mounted subrouters.
+package chi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/routers"
+	chipkg "github.com/go-chi/chi/v5"
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+// Middleware returns a Chi middleware validating requests, and responses when ValidateResponses
+// is enabled, against the operation matching the Chi route pattern. Failures are written with
+// the validator's ErrorEncoder. Requests served outside a Chi router fall back to
+// Validator.Middleware.
+func Middleware(v *validator.Validator, opts ...validator.AdapterOption) func(http.Handler) http.Handler {
+	cfg := validator.NewAdapterOptions(opts...)
+
+	return func(next http.Handler) http.Handler {
+		fallback := v.Middleware(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chipkg.RouteContext(r.Context())
+			if rctx == nil || rctx.Routes == nil {
+				fallback.ServeHTTP(w, r)
+				return
+			}
+			if strings.HasPrefix(r.URL.Path, v.Options.SwaggerUIPath) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Middleware registered with Mux.Use runs before routing, so match the full request
+			// path against the root router instead of relying on the partial route context.
+			match := chipkg.NewRouteContext()
+			if !rctx.Routes.Match(match, r.Method, requestPath(r)) {
+				// Chi answers unrouted requests itself.
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			pattern := match.RoutePattern()
+			path := cfg.OperationPath(pattern)
+			route, err := v.OperationRoute(r.Method, path)
+			if err != nil {
+				rejection := v.UnmatchedRoute(r, err)
+				if rejection == nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+				if v.Options.UnmatchedRoutePolicy == validator.UnmatchedRouteReject && errors.Is(err, routers.ErrMethodNotAllowed) {
+					w.Header().Set("Allow", strings.Join(v.PathMethods(path), ", "))
+				}
+				v.Options.ErrorEncoder(w, r, rejection)
+				return
+			}
+
+			pathParams := validator.PathParamsFor(route.Path, urlParamValues(pattern, match.URLParams))
+			r, err = v.ValidateRequest(r, route, pathParams)
+			if err != nil {
+				v.Options.ErrorEncoder(w, r, err)
+				return
+			}
+
+			if v.Options.ValidateResponses {
+				v.ServeResponse(w, r, next, route, pathParams)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestPath returns the path Chi routes on.
+func requestPath(r *http.Request) string {
+	if r.URL.RawPath != "" {
+		return r.URL.RawPath
+	}
+	return r.URL.Path
+}
+
+// urlParamValues returns the values of the parameters of pattern in order. Mounted subrouters
+// add a "*" parameter for the rest of the path, which is only kept for a trailing catch-all.
+func urlParamValues(pattern string, params chipkg.RouteParams) []string {
+	var values []string
+	wildcard, hasWildcard := "", false
+	for i, key := range params.Keys {
+		if key == "*" {
+			wildcard, hasWildcard = params.Values[i], true
+			continue
+		}
+		values = append(values, params.Values[i])
+	}
+	if hasWildcard && strings.HasSuffix(pattern, "/*") {
+		values = append(values, wildcard)
+	}
+	return values
+}
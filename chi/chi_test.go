@@ -0,0 +1,170 @@
+package chi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	chipkg "github.com/go-chi/chi/v5"
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{userId}:
+    get:
+      operationId: getUser
+      parameters:
+        - {name: userId, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+`
+
+func newValidator(t *testing.T, opts ...validator.Option) *validator.Validator {
+	t.Helper()
+	v, err := validator.NewFromBytes([]byte(testSpec), opts...)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	return v
+}
+
+func TestMiddleware(t *testing.T) {
+	v := newValidator(t)
+
+	var op validator.OperationInfo
+	r := chipkg.NewRouter()
+	r.Use(Middleware(v))
+	r.Get("/users/{id:[a-z0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		op, _ = validator.OperationFromContext(r.Context())
+	})
+	r.Get("/other", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("other"))
+	})
+
+	t.Run("Valid Request", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		r.ServeHTTP(rec, httptest.NewRequest("GET", "/users/42", nil))
+
+		// Assert
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if op.ID != "getUser" {
+			t.Errorf("expected operation getUser, got %+v", op)
+		}
+	})
+
+	t.Run("Invalid Request", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		r.ServeHTTP(rec, httptest.NewRequest("GET", "/users/abc", nil))
+
+		// Assert
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rec.Code)
+		}
+		var body validator.ValidationError
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if len(body.Details) == 0 || body.Details[0].Name != "userId" {
+			t.Errorf("expected a userId field error, got %+v", body.Details)
+		}
+	})
+
+	t.Run("Undocumented Route Passes Through", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		r.ServeHTTP(rec, httptest.NewRequest("GET", "/other", nil))
+
+		// Assert
+		if rec.Code != http.StatusOK || rec.Body.String() != "other" {
+			t.Errorf("expected pass through, got %d %q", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+func TestMiddlewareMountedSubrouter(t *testing.T) {
+	// Arrange
+	v := newValidator(t)
+	r := chipkg.NewRouter()
+	r.Route("/api/v1", func(r chipkg.Router) {
+		r.Use(Middleware(v, validator.WithPathPrefix("/api/v1")))
+		r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	})
+
+	t.Run("Invalid Request", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		r.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/users/abc", nil))
+
+		// Assert
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Valid Request", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		r.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/users/7", nil))
+
+		// Assert
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", rec.Code)
+		}
+	})
+}
+
+func TestMiddlewareUnmatchedRoutePolicy(t *testing.T) {
+	// Arrange
+	v := newValidator(t, validator.WithUnmatchedRoutePolicy(validator.UnmatchedRouteReject))
+	r := chipkg.NewRouter()
+	r.Use(Middleware(v))
+	r.Delete("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	rec := httptest.NewRecorder()
+
+	// Act
+	r.ServeHTTP(rec, httptest.NewRequest("DELETE", "/users/1", nil))
+
+	// Assert
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "GET" {
+		t.Errorf("expected Allow GET, got %q", allow)
+	}
+}
+
+func TestMiddlewareOutsideChi(t *testing.T) {
+	// Arrange
+	v := newValidator(t)
+	handler := Middleware(v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/users/abc", nil))
+
+	// Assert
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
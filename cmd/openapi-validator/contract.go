@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	validator "github.com/vihuvac/go-openapi-validator"
+	"github.com/vihuvac/go-openapi-validator/contracttest"
+)
+
+// headerFlags collects repeated -header flags.
+type headerFlags []string
+
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headerFlags) Set(value string) error {
+	if !strings.Contains(value, ":") {
+		return fmt.Errorf("header %q is not in Name: value form", value)
+	}
+	*h = append(*h, value)
+	return nil
+}
+
+// runContract implements the contract command.
+func runContract(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("contract", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	spec := fs.String("spec", "", "path of the OpenAPI spec (required)")
+	format := fs.String("format", "text", "output format: text or json")
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout of each request")
+	var headers headerFlags
+	fs.Var(&headers, "header", `header added to every request, e.g. "Authorization: Bearer token" (repeatable)`)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: openapi-validator contract -spec <spec> [flags] <base-url>")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Sends a request generated from the spec to every operation of the service at base-url")
+		fmt.Fprintln(stderr, "and validates the responses. Unsafe methods are called too: use a disposable instance.")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitError
+	}
+	if *spec == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	write, ok := map[string]func(io.Writer, *contracttest.Report) error{
+		"text": contracttest.WriteText,
+		"json": contracttest.WriteJSON,
+	}[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "contract: unknown format %q\n", *format)
+		return exitError
+	}
+
+	v, err := validator.New(*spec)
+	if err != nil {
+		fmt.Fprintf(stderr, "contract: %v\n", err)
+		return exitError
+	}
+
+	opts := []contracttest.Option{contracttest.WithHTTPClient(&http.Client{Timeout: *timeout})}
+	if len(headers) > 0 {
+		opts = append(opts, contracttest.WithRequestEditor(func(r *http.Request) error {
+			for _, h := range headers {
+				name, value, _ := strings.Cut(h, ":")
+				r.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+			}
+			return nil
+		}))
+	}
+
+	report, err := contracttest.Run(context.Background(), v, fs.Arg(0), opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "contract: %v\n", err)
+		return exitError
+	}
+	if err := write(stdout, report); err != nil {
+		fmt.Fprintf(stderr, "contract: %v\n", err)
+		return exitError
+	}
+	if !report.OK() {
+		return exitProblems
+	}
+	return exitOK
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunContract(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(testReplaySpec), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	var authorization string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	t.Run("Conformant Service", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run([]string{"contract", "-spec", specPath, "-header", "Authorization: Bearer token", srv.URL}, &stdout, &stderr)
+
+		// Assert
+		if code != exitOK {
+			t.Errorf("expected exit code 0, got %d: %s", code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), "PASS       GET /pets (listPets): 200") {
+			t.Errorf("unexpected output: %q", stdout.String())
+		}
+		if authorization != "Bearer token" {
+			t.Errorf("expected the -header flag to be sent, got %q", authorization)
+		}
+	})
+
+	t.Run("Drifting Service", func(t *testing.T) {
+		// Arrange
+		status = http.StatusTeapot
+		defer func() { status = http.StatusOK }()
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run([]string{"contract", "-spec", specPath, "-format", "json", srv.URL}, &stdout, &stderr)
+
+		// Assert
+		if code != exitProblems {
+			t.Errorf("expected exit code 1, got %d", code)
+		}
+		if !strings.Contains(stdout.String(), `"status": "fail"`) {
+			t.Errorf("unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("Usage Errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"contract", srv.URL},
+			{"contract", "-spec", specPath},
+			{"contract", "-spec", specPath, "-format", "xml", srv.URL},
+			{"contract", "-spec", specPath, "-header", "Authorization", srv.URL},
+			{"contract", "-spec", specPath, "localhost:8080"},
+			{"contract", "-spec", filepath.Join(dir, "missing.yaml"), srv.URL},
+		} {
+			// Arrange
+			var stdout, stderr bytes.Buffer
+
+			// Act
+			code := run(args, &stdout, &stderr)
+
+			// Assert
+			if code != exitError {
+				t.Errorf("%v: expected exit code 2, got %d", args, code)
+			}
+		}
+	})
+}
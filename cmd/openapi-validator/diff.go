@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	validator "github.com/vihuvac/go-openapi-validator"
+	"github.com/vihuvac/go-openapi-validator/diff"
+)
+
+// runDiff implements the diff command.
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "markdown", "output format: markdown or json")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: openapi-validator diff [flags] <base> <revision>")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Compares two versions of a spec and classifies the changes as breaking or non-breaking.")
+		fmt.Fprintln(stderr, "The exit code is 1 when there are breaking changes.")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitError
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitError
+	}
+
+	write, ok := map[string]func(io.Writer, *diff.Report) error{
+		"markdown": diff.WriteMarkdown,
+		"json":     diff.WriteJSON,
+	}[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "diff: unknown format %q\n", *format)
+		return exitError
+	}
+
+	base, err := validator.New(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "diff: %s: %v\n", fs.Arg(0), err)
+		return exitError
+	}
+	revision, err := validator.New(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(stderr, "diff: %s: %v\n", fs.Arg(1), err)
+		return exitError
+	}
+
+	report := diff.Compare(base.Spec(), revision.Spec())
+	if err := write(stdout, report); err != nil {
+		fmt.Fprintf(stderr, "diff: %v\n", err)
+		return exitError
+	}
+	if len(report.Breaking()) > 0 {
+		return exitProblems
+	}
+	return exitOK
+}
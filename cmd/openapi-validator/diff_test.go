@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.yaml")
+	compatiblePath := filepath.Join(dir, "compatible.yaml")
+	breakingPath := filepath.Join(dir, "breaking.yaml")
+	for name, content := range map[string]string{
+		basePath:       testReplaySpec,
+		compatiblePath: strings.Replace(testReplaySpec, "      responses:\n", "      parameters:\n        - {name: limit, in: query, schema: {type: integer}}\n      responses:\n", 1),
+		breakingPath:   strings.Replace(testReplaySpec, "    get:\n", "    post:\n", 1),
+	} {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	t.Run("Non-Breaking Changes", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run([]string{"diff", basePath, compatiblePath}, &stdout, &stderr)
+
+		// Assert
+		if code != exitOK {
+			t.Errorf("expected exit code 0, got %d: %s", code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), "**0 breaking**, 1 non-breaking changes.") {
+			t.Errorf("unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("Breaking Changes", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run([]string{"diff", "-format", "json", basePath, breakingPath}, &stdout, &stderr)
+
+		// Assert
+		if code != exitProblems {
+			t.Errorf("expected exit code 1, got %d", code)
+		}
+		if !strings.Contains(stdout.String(), `"breaking": 1`) {
+			t.Errorf("unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("Usage Errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"diff", basePath},
+			{"diff", "-format", "html", basePath, breakingPath},
+			{"diff", filepath.Join(dir, "missing.yaml"), basePath},
+			{"diff", basePath, filepath.Join(dir, "missing.yaml")},
+		} {
+			// Arrange
+			var stdout, stderr bytes.Buffer
+
+			// Act
+			code := run(args, &stdout, &stderr)
+
+			// Assert
+			if code != exitError {
+				t.Errorf("%v: expected exit code 2, got %d", args, code)
+			}
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vihuvac/go-openapi-validator/lint"
+)
+
+// runLint implements the lint command.
+func runLint(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "text", "output format: text, json or sarif")
+	rules := fs.String("rules", "", `comma-separated opt-in rules to apply, or "all"`)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: openapi-validator lint [flags] <spec>")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Loads the spec, resolving external $refs, and reports load and validation errors")
+		fmt.Fprintln(stderr, "together with the findings of the selected rules.")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		fs.PrintDefaults()
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Rules:")
+		for _, rule := range lint.OptionalRules() {
+			fmt.Fprintf(stderr, "  %-22s %s\n", rule, rule.Description())
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	write, ok := map[string]func(io.Writer, []lint.Finding) error{
+		"text":  lint.WriteText,
+		"json":  lint.WriteJSON,
+		"sarif": lint.WriteSARIF,
+	}[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "lint: unknown format %q\n", *format)
+		return exitError
+	}
+
+	var opts []lint.Option
+	switch *rules {
+	case "":
+	case "all":
+		opts = append(opts, lint.WithRules(lint.OptionalRules()...))
+	default:
+		for _, rule := range strings.Split(*rules, ",") {
+			opts = append(opts, lint.WithRules(lint.Rule(strings.TrimSpace(rule))))
+		}
+	}
+
+	findings, err := lint.Lint(fs.Arg(0), opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "lint: %v\n", err)
+		return exitError
+	}
+	if err := write(stdout, findings); err != nil {
+		fmt.Fprintf(stderr, "lint: %v\n", err)
+		return exitError
+	}
+	if len(findings) > 0 {
+		return exitProblems
+	}
+	return exitOK
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testLintSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+
+func TestRunLint(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(testLintSpec), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	t.Run("Clean Spec", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run([]string{"lint", specPath}, &stdout, &stderr)
+
+		// Assert
+		if code != exitOK || stdout.Len() != 0 {
+			t.Errorf("expected exit code 0 and no output, got %d %q %q", code, stdout.String(), stderr.String())
+		}
+	})
+
+	t.Run("Findings", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run([]string{"lint", "-rules", "missing-operation-id, undocumented-4xx", specPath}, &stdout, &stderr)
+
+		// Assert
+		if code != exitProblems {
+			t.Errorf("expected exit code 1, got %d", code)
+		}
+		if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], specPath+":7:5: warning:") {
+			t.Errorf("unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("SARIF Output", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run([]string{"lint", "-format", "sarif", "-rules", "all", specPath}, &stdout, &stderr)
+
+		// Assert
+		if code != exitProblems {
+			t.Errorf("expected exit code 1, got %d", code)
+		}
+		var log struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &log); err != nil || log.Version != "2.1.0" {
+			t.Errorf("expected a SARIF log, got %q", stdout.String())
+		}
+	})
+
+	t.Run("Usage Errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"lint"},
+			{"lint", "-format", "xml", specPath},
+			{"lint", "-rules", "bogus", specPath},
+			{"lint", filepath.Join(t.TempDir(), "missing.yaml")},
+		} {
+			// Arrange
+			var stdout, stderr bytes.Buffer
+
+			// Act
+			code := run(args, &stdout, &stderr)
+
+			// Assert
+			if code != exitError {
+				t.Errorf("%v: expected exit code 2, got %d", args, code)
+			}
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// Command openapi-validator checks OpenAPI specs outside of a running service.
+//
+// Usage:
+//
+//	openapi-validator <command> [flags] [arguments]
+//
+// Run "openapi-validator <command> -h" for the flags of a command.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit codes shared by every command.
+const (
+	// exitOK means the command ran and found no problems.
+	exitOK = 0
+	// exitProblems means the command ran and found problems, e.g. lint findings.
+	exitProblems = 1
+	// exitError means the command could not run: bad usage, unreadable input and the like.
+	exitError = 2
+)
+
+// command is a subcommand of the tool.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+// commands lists the subcommands in the order they are shown in the usage.
+var commands = []command{
+	{name: "lint", summary: "validate a spec and apply optional style rules", run: runLint},
+	{name: "replay", summary: "validate recorded traffic (HAR files) against a spec", run: runReplay},
+	{name: "diff", summary: "detect breaking changes between two versions of a spec", run: runDiff},
+	{name: "contract", summary: "test a running service against a spec", run: runContract},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run dispatches args to the named command and returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return exitError
+		}
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "openapi-validator: unknown command %q\n\n", args[0])
+	usage(stderr)
+	return exitError
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: openapi-validator <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit codes: 0 no problems, 1 problems found, 2 error.")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		contains string
+	}{
+		{"No Command", nil, exitError, "Usage: openapi-validator"},
+		{"Help", []string{"help"}, exitOK, "lint"},
+		{"Unknown Command", []string{"bogus"}, exitError, `unknown command "bogus"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var stdout, stderr bytes.Buffer
+
+			// Act
+			code := run(tt.args, &stdout, &stderr)
+
+			// Assert
+			if code != tt.code {
+				t.Errorf("expected exit code %d, got %d", tt.code, code)
+			}
+			if !strings.Contains(stderr.String(), tt.contains) {
+				t.Errorf("expected stderr to contain %q, got %q", tt.contains, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_ParentDirectoryRefs(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "api", "openapi.yaml")
+	petPath := filepath.Join(dir, "common", "pet.yaml")
+	harPath := filepath.Join(dir, "traffic.har")
+	for name, content := range map[string]string{
+		specPath: `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '../common/pet.yaml'}
+`,
+		petPath: "type: object\n",
+		harPath: `{"log":{"entries":[{"request":{"method":"GET","url":"http://localhost/pets"},"response":{"status":200,"content":{"mimeType":"application/json","text":"{}"}}}]}}`,
+	} {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(name), err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"lint", specPath},
+		{"replay", "-spec", specPath, harPath},
+		{"diff", specPath, specPath},
+	} {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run(args, &stdout, &stderr)
+
+		// Assert
+		if code != exitOK {
+			t.Errorf("%v: expected exit code 0, got %d: %s", args[0], code, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	validator "github.com/vihuvac/go-openapi-validator"
+	"github.com/vihuvac/go-openapi-validator/har"
+)
+
+// runReplay implements the replay command.
+func runReplay(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	spec := fs.String("spec", "", "path of the OpenAPI spec (required)")
+	format := fs.String("format", "text", "output format: text or json")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: openapi-validator replay -spec <spec> [flags] <file.har>...")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Replays the recorded requests and responses through request and response validation")
+		fmt.Fprintln(stderr, "and reports violations, unmatched routes and undeclared status codes per operation.")
+		fmt.Fprintln(stderr)
+		fmt.Fprintln(stderr, "Flags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitError
+	}
+	if *spec == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	write, ok := map[string]func(io.Writer, *har.Report) error{
+		"text": har.WriteText,
+		"json": har.WriteJSON,
+	}[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "replay: unknown format %q\n", *format)
+		return exitError
+	}
+
+	v, err := validator.New(*spec)
+	if err != nil {
+		fmt.Fprintf(stderr, "replay: %v\n", err)
+		return exitError
+	}
+
+	// Several archives are replayed as a single log so the report covers them all.
+	log := &har.Log{}
+	for _, name := range fs.Args() {
+		l, err := readHAR(name)
+		if err != nil {
+			fmt.Fprintf(stderr, "replay: %s: %v\n", name, err)
+			return exitError
+		}
+		log.Entries = append(log.Entries, l.Entries...)
+	}
+
+	report := har.Replay(v, log)
+	if err := write(stdout, report); err != nil {
+		fmt.Fprintf(stderr, "replay: %v\n", err)
+		return exitError
+	}
+	if !report.OK() {
+		return exitProblems
+	}
+	return exitOK
+}
+
+// readHAR decodes the HTTP Archive in the named file.
+func readHAR(name string) (*har.Log, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return har.Decode(f)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testReplaySpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+`
+
+func TestRunReplay(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	conformant := filepath.Join(dir, "conformant.har")
+	drifting := filepath.Join(dir, "drifting.har")
+	for name, content := range map[string]string{
+		specPath:   testReplaySpec,
+		conformant: `{"log":{"entries":[{"request":{"method":"GET","url":"http://localhost/pets"},"response":{"status":200,"content":{}}}]}}`,
+		drifting:   `{"log":{"entries":[{"request":{"method":"GET","url":"http://localhost/pets"},"response":{"status":418,"content":{}}}]}}`,
+	} {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	t.Run("Conformant Traffic", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run([]string{"replay", "-spec", specPath, conformant}, &stdout, &stderr)
+
+		// Assert
+		if code != exitOK {
+			t.Errorf("expected exit code 0, got %d: %s", code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), "GET /pets (listPets): 1 entries, 1 passed") {
+			t.Errorf("unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("Several Archives", func(t *testing.T) {
+		// Arrange
+		var stdout, stderr bytes.Buffer
+
+		// Act
+		code := run([]string{"replay", "-spec", specPath, "-format", "json", conformant, drifting}, &stdout, &stderr)
+
+		// Assert
+		if code != exitProblems {
+			t.Errorf("expected exit code 1, got %d", code)
+		}
+		if !strings.Contains(stdout.String(), `"entries": 2`) || !strings.Contains(stdout.String(), `"418": 1`) {
+			t.Errorf("unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("Usage Errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"replay", conformant},
+			{"replay", "-spec", specPath},
+			{"replay", "-spec", specPath, "-format", "xml", conformant},
+			{"replay", "-spec", filepath.Join(dir, "missing.yaml"), conformant},
+			{"replay", "-spec", specPath, filepath.Join(dir, "missing.har")},
+		} {
+			// Arrange
+			var stdout, stderr bytes.Buffer
+
+			// Act
+			code := run(args, &stdout, &stderr)
+
+			// Assert
+			if code != exitError {
+				t.Errorf("%v: expected exit code 2, got %d", args, code)
+			}
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package openapi_validator
+
+import (
+	"context"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// contextKey is the type of the keys this package stores on request contexts.
+type contextKey int
+
+const (
+	principalsKey contextKey = iota
+	routeKey
+	paramsKey
+)
+
+// OperationInfo describes the OpenAPI operation a request was matched to.
+type OperationInfo struct {
+	// ID is the operationId, empty if the spec does not declare one.
+	ID string
+	// Method is the HTTP method of the operation.
+	Method string
+	// Path is the path template of the operation as written in the spec, e.g. /users/{id}.
+	Path string
+	// Tags are the tags of the operation.
+	Tags []string
+	// Operation is the full operation definition.
+	Operation *openapi3.Operation
+	// Route is the route returned by the router.
+	Route *routers.Route
+}
+
+// matchedRoute is what the middleware stores on the request context once a route is found.
+type matchedRoute struct {
+	route      *routers.Route
+	pathParams map[string]string
+}
+
+// withRoute returns a copy of ctx carrying the matched route and its path parameters.
+func withRoute(ctx context.Context, route *routers.Route, pathParams map[string]string) context.Context {
+	return context.WithValue(ctx, routeKey, &matchedRoute{route: route, pathParams: pathParams})
+}
+
+// OperationFromContext returns the OpenAPI operation the request was matched to by the middleware.
+// It returns false for requests that matched no operation.
+func OperationFromContext(ctx context.Context) (OperationInfo, bool) {
+	matched, ok := ctx.Value(routeKey).(*matchedRoute)
+	if !ok || matched.route == nil || matched.route.Operation == nil {
+		return OperationInfo{}, false
+	}
+
+	route := matched.route
+	return OperationInfo{
+		ID:        route.Operation.OperationID,
+		Method:    route.Method,
+		Path:      route.Path,
+		Tags:      route.Operation.Tags,
+		Operation: route.Operation,
+		Route:     route,
+	}, true
+}
+
+// PathParamsFromContext returns the path parameters of the matched operation, keyed by the names
+// used in the path template. It returns nil for requests that matched no operation.
+func PathParamsFromContext(ctx context.Context) map[string]string {
+	matched, ok := ctx.Value(routeKey).(*matchedRoute)
+	if !ok {
+		return nil
+	}
+	return matched.pathParams
+}
+
+// PrincipalsFromContext returns the principals produced by the authenticators of the satisfied
+// security requirement, keyed by security scheme name. It returns nil if the request was not authenticated.
+func PrincipalsFromContext(ctx context.Context) map[string]any {
+	principals, _ := ctx.Value(principalsKey).(map[string]any)
+	return principals
+}
+
+// PrincipalFromContext returns the principal of the authenticated request. When the satisfied
+// security requirement combines several schemes, the first non-nil principal in scheme name order is returned.
+func PrincipalFromContext(ctx context.Context) any {
+	principals := PrincipalsFromContext(ctx)
+	for _, name := range mapkeys.Sorted(principals) {
+		if p := principals[name]; p != nil {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package openapi_validator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOperationFromContext(t *testing.T) {
+	v, err := NewFromBytes([]byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      tags: [users]
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+`))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	var (
+		op     OperationInfo
+		found  bool
+		params map[string]string
+	)
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, found = OperationFromContext(r.Context())
+		params = PathParamsFromContext(r.Context())
+	}))
+
+	t.Run("Matched Operation", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/users/42", nil)
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if !found {
+			t.Fatal("expected operation to be found")
+		}
+
+		if op.ID != "getUser" || op.Method != "GET" || op.Path != "/users/{id}" {
+			t.Errorf("unexpected operation %+v", op)
+		}
+
+		if len(op.Tags) != 1 || op.Tags[0] != "users" {
+			t.Errorf("expected tags [users], got %v", op.Tags)
+		}
+
+		if params["id"] != "42" {
+			t.Errorf("expected path param id 42, got %v", params)
+		}
+	})
+
+	t.Run("Unmatched Request", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/unknown", nil)
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if found || params != nil {
+			t.Errorf("expected no operation for an unmatched request, got %+v %v", op, params)
+		}
+	})
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	// Arrange
+	ctx := context.WithValue(context.Background(), principalsKey, map[string]any{"b": "second", "a": nil, "c": "third"})
+
+	// Act
+	got := PrincipalFromContext(ctx)
+
+	// Assert
+	if got != "second" {
+		t.Errorf("expected the first non-nil principal, got %v", got)
+	}
+}
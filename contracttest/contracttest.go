@@ -0,0 +1,189 @@
+// Package contracttest verifies a running service against its OpenAPI spec. Run walks every
+// operation, sends a request generated from the spec's examples or schemas to the service and
+// validates the response with the validator's response validation. It works against deployed
+// services as well as httptest.Server instances in Go tests:
+//
+//	srv := httptest.NewServer(handler)
+//	defer srv.Close()
+//	report, err := contracttest.Run(ctx, v, srv.URL)
+package contracttest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	validator "github.com/vihuvac/go-openapi-validator"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// Status is the outcome of an operation's contract test.
+type Status string
+
+const (
+	// StatusPass means the response matched the spec.
+	StatusPass Status = "pass"
+	// StatusFail means the response did not match the spec, or no response was received.
+	StatusFail Status = "fail"
+	// StatusUntestable means no valid request could be generated for the operation.
+	StatusUntestable Status = "untestable"
+)
+
+// Result is the outcome of the contract test of one operation.
+type Result struct {
+	OperationID string `json:"operationId,omitempty"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Status      Status `json:"status"`
+	// URL is the URL the request was sent to, and StatusCode the status of the response.
+	URL        string `json:"url,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	// Reason explains untestable operations and failures without a response.
+	Reason string `json:"reason,omitempty"`
+	// Errors lists the response validation failures.
+	Errors []validator.FieldError `json:"errors,omitempty"`
+}
+
+// Report is the outcome of a contract test run, one result per operation ordered by path and method.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Count returns the number of results with status s.
+func (r *Report) Count(s Status) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+// OK reports whether no operation failed. Untestable operations do not count as failures.
+func (r *Report) OK() bool {
+	return r.Count(StatusFail) == 0
+}
+
+// Option is a function type used to configure Run.
+type Option func(*config)
+
+type config struct {
+	client  *http.Client
+	editors []func(*http.Request) error
+}
+
+// WithHTTPClient returns an Option that sends the requests with client instead of http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.client = client
+	}
+}
+
+// WithRequestEditor returns an Option that calls edit on every request before it is sent,
+// e.g. to add credentials for secured operations.
+func WithRequestEditor(edit func(*http.Request) error) Option {
+	return func(c *config) {
+		c.editors = append(c.editors, edit)
+	}
+}
+
+// Run sends one request per operation of the spec of v to the service at baseURL (e.g.
+// "http://localhost:8080" or an httptest.Server URL; the spec's paths are appended to it) and
+// validates the responses. The returned error is only set when baseURL is invalid.
+//
+// Requests are built from the examples of parameters and request bodies, or from values
+// synthesized from their schemas like MockHandler does, and are checked against the spec before
+// being sent. Operations needing values that cannot be generated are reported as untestable.
+// Every operation is called, including unsafe methods, so point Run at a disposable instance.
+func Run(ctx context.Context, v *validator.Validator, baseURL string, opts ...Option) (*Report, error) {
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme and host are required", baseURL)
+	}
+
+	cfg := &config{client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	report := &Report{Results: []Result{}}
+	doc := v.Spec()
+	paths := doc.Paths.Map()
+	for _, path := range mapkeys.Sorted(paths) {
+		for _, method := range mapkeys.Sorted(paths[path].Operations()) {
+			report.Results = append(report.Results, runOperation(ctx, v, cfg, base, method, path))
+		}
+	}
+	return report, nil
+}
+
+// runOperation tests the operation declared for method on path.
+func runOperation(ctx context.Context, v *validator.Validator, cfg *config, base *url.URL, method, path string) Result {
+	result := Result{Method: method, Path: path}
+	route, err := v.OperationRoute(method, path)
+	if err != nil {
+		result.Status, result.Reason = StatusFail, err.Error()
+		return result
+	}
+	result.OperationID = route.Operation.OperationID
+
+	req, pathParams, body, err := newRequest(ctx, route, base)
+	if err != nil {
+		result.Status, result.Reason = StatusUntestable, err.Error()
+		return result
+	}
+	result.URL = req.URL.String()
+	for _, edit := range cfg.editors {
+		if err := edit(req); err != nil {
+			result.Status, result.Reason = StatusUntestable, err.Error()
+			return result
+		}
+	}
+
+	// A request the service may legitimately reject says nothing about its contract.
+	if err := validateRequest(ctx, req, route, pathParams); err != nil {
+		result.Status, result.Reason = StatusUntestable, "generated request does not match the spec: "+err.Error()
+		return result
+	}
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := cfg.client.Do(req)
+	if err != nil {
+		result.Status, result.Reason = StatusFail, err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.Status, result.Reason = StatusFail, fmt.Sprintf("failed to read response: %v", err)
+		return result
+	}
+
+	responses := route.Operation.Responses
+	if responses == nil || (responses.Status(resp.StatusCode) == nil && responses.Default() == nil) {
+		result.Status, result.Reason = StatusFail, fmt.Sprintf("status %d is not declared", resp.StatusCode)
+		return result
+	}
+	if err := v.ValidateResponse(req, route, pathParams, resp.StatusCode, resp.Header, data); err != nil {
+		result.Status, result.Errors = StatusFail, validator.FieldErrors(err)
+		return result
+	}
+	result.Status = StatusPass
+	return result
+}
+
+// errUntestable reports a value that cannot be generated.
+var errUntestable = errors.New("no value can be generated")
@@ -0,0 +1,197 @@
+package contracttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+const testContractSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - {name: limit, in: query, required: true, schema: {type: integer, minimum: 1, example: 5}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: '#/components/schemas/Pet'}}
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+    delete:
+      operationId: deletePet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '204': {description: Deleted}
+  /search:
+    get:
+      operationId: search
+      parameters:
+        - {name: filter, in: query, required: true, schema: {type: object, properties: {q: {type: string}}}}
+      responses:
+        '200': {description: OK}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id: {type: integer, readOnly: true}
+        name: {type: string, example: Rex}
+`
+
+// newTestService answers listPets and createPet correctly, getPet with an invalid body and
+// deletePet with an undeclared status.
+func newTestService(t *testing.T, requests map[string]*http.Request) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pets", func(w http.ResponseWriter, r *http.Request) {
+		requests["listPets"] = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":1,"name":"Rex"}]`))
+	})
+	mux.HandleFunc("POST /pets", func(w http.ResponseWriter, r *http.Request) {
+		requests["createPet"] = r
+		var pet map[string]any
+		json.NewDecoder(r.Body).Decode(&pet)
+		pet["id"] = 2
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(pet)
+	})
+	mux.HandleFunc("GET /pets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"one"}`))
+	})
+	mux.HandleFunc("DELETE /pets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRun(t *testing.T) {
+	// Arrange
+	v, err := validator.NewFromBytes([]byte(testContractSpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	requests := make(map[string]*http.Request)
+	var bodies []string
+	srv := newTestService(t, requests)
+
+	// Act
+	report, err := Run(context.Background(), v, srv.URL, WithRequestEditor(func(r *http.Request) error {
+		r.Header.Set("Authorization", "Bearer test")
+		if r.Body != nil && r.Body != http.NoBody {
+			data, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(data))
+			r.Body = io.NopCloser(bytes.NewReader(data))
+		}
+		return nil
+	}))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]Status{
+		"listPets":  StatusPass,
+		"createPet": StatusPass,
+		"getPet":    StatusFail,
+		"deletePet": StatusFail,
+		"search":    StatusUntestable,
+	}
+	if len(report.Results) != len(expected) {
+		t.Fatalf("expected %d results, got %+v", len(expected), report.Results)
+	}
+	for _, r := range report.Results {
+		if r.Status != expected[r.OperationID] {
+			t.Errorf("%s: expected %s, got %+v", r.OperationID, expected[r.OperationID], r)
+		}
+	}
+	if report.OK() || report.Count(StatusPass) != 2 {
+		t.Errorf("expected two passes and failures, got %+v", report.Results)
+	}
+
+	if r := requests["listPets"]; r == nil || r.URL.RawQuery != "limit=5" || r.Header.Get("Authorization") != "Bearer test" {
+		t.Errorf("expected the example query parameter and the edited header, got %+v", r)
+	}
+	if len(bodies) != 1 || bodies[0] != `{"name":"Rex"}` {
+		t.Errorf("expected the readOnly id to be left out of the body, got %q", bodies)
+	}
+}
+
+func TestRun_InvalidBaseURL(t *testing.T) {
+	// Arrange
+	v, err := validator.NewFromBytes([]byte(testContractSpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	for _, baseURL := range []string{"localhost:8080", "http://[::1", ""} {
+		// Act
+		_, err := Run(context.Background(), v, baseURL)
+
+		// Assert
+		if err == nil {
+			t.Errorf("%q: expected an error", baseURL)
+		}
+	}
+}
+
+func TestRun_Unreachable(t *testing.T) {
+	// Arrange
+	v, err := validator.NewFromBytes([]byte(testContractSpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	// Act
+	report, err := Run(context.Background(), v, "http://127.0.0.1:1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Count(StatusFail) != 4 || report.Results[0].Reason == "" {
+		t.Errorf("expected every testable operation to fail with a reason, got %+v", report.Results)
+	}
+}
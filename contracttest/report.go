@@ -0,0 +1,47 @@
+package contracttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteText writes one line per operation with its status, followed by the details of failures
+// and untestable operations and a summary.
+func WriteText(w io.Writer, report *Report) error {
+	var b strings.Builder
+	for _, r := range report.Results {
+		name := r.Method + " " + r.Path
+		if r.OperationID != "" {
+			name += " (" + r.OperationID + ")"
+		}
+		if r.StatusCode != 0 {
+			fmt.Fprintf(&b, "%-10s %s: %d\n", strings.ToUpper(string(r.Status)), name, r.StatusCode)
+		} else {
+			fmt.Fprintf(&b, "%-10s %s\n", strings.ToUpper(string(r.Status)), name)
+		}
+		if r.Reason != "" {
+			fmt.Fprintf(&b, "           %s\n", r.Reason)
+		}
+		for _, e := range r.Errors {
+			location := strings.TrimSpace(e.In + " " + e.Pointer)
+			if location != "" {
+				fmt.Fprintf(&b, "           %s: %s\n", location, e.Message)
+			} else {
+				fmt.Fprintf(&b, "           %s\n", e.Message)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "%d operations: %d passed, %d failed, %d untestable\n",
+		len(report.Results), report.Count(StatusPass), report.Count(StatusFail), report.Count(StatusUntestable))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the report as JSON.
+func WriteJSON(w io.Writer, report *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,69 @@
+package contracttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+var testReport = &Report{Results: []Result{
+	{OperationID: "listPets", Method: "GET", Path: "/pets", Status: StatusPass, URL: "http://localhost/pets", StatusCode: 200},
+	{
+		OperationID: "getPet",
+		Method:      "GET",
+		Path:        "/pets/{id}",
+		Status:      StatusFail,
+		URL:         "http://localhost/pets/1",
+		StatusCode:  200,
+		Errors:      []validator.FieldError{{In: "body", Pointer: "/id", Message: "value must be an integer"}},
+	},
+	{OperationID: "search", Method: "GET", Path: "/search", Status: StatusUntestable, Reason: "cannot encode object parameter filter"},
+}}
+
+func TestWriteText(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := WriteText(&buf, testReport)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range []string{
+		"PASS       GET /pets (listPets): 200\n",
+		"FAIL       GET /pets/{id} (getPet): 200\n",
+		"           body /id: value must be an integer\n",
+		"UNTESTABLE GET /search (search)\n",
+		"           cannot encode object parameter filter\n",
+		"3 operations: 1 passed, 1 failed, 1 untestable\n",
+	} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("expected the output to contain %q, got:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := WriteJSON(&buf, testReport)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+	if len(decoded.Results) != 3 || decoded.Results[1].Status != StatusFail || decoded.Results[1].Errors[0].Pointer != "/id" {
+		t.Errorf("expected the report to round-trip, got %+v", decoded)
+	}
+}
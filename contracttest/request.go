@@ -0,0 +1,254 @@
+package contracttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	validator "github.com/vihuvac/go-openapi-validator"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// newRequest builds the request for route below base. It returns the path parameters and the
+// encoded body alongside, so the request can be validated and its body restored afterwards.
+func newRequest(ctx context.Context, route *routers.Route, base *url.URL) (*http.Request, map[string]string, []byte, error) {
+	path := route.Path
+	pathParams := make(map[string]string)
+	query := url.Values{}
+	header := http.Header{}
+	var cookies []*http.Cookie
+
+	for _, p := range parameters(route) {
+		// Optional parameters are left out, the smallest valid request is the most telling.
+		if !p.Required && p.In != openapi3.ParameterInPath {
+			continue
+		}
+		values, err := paramValues(p)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s parameter %s: %w", p.In, p.Name, err)
+		}
+		switch p.In {
+		case openapi3.ParameterInPath:
+			pathParams[p.Name] = values[0]
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", url.PathEscape(values[0]))
+		case openapi3.ParameterInQuery:
+			query[p.Name] = values
+		case openapi3.ParameterInHeader:
+			header.Set(p.Name, strings.Join(values, ","))
+		case openapi3.ParameterInCookie:
+			cookies = append(cookies, &http.Cookie{Name: p.Name, Value: strings.Join(values, ",")})
+		}
+	}
+
+	var (
+		body        []byte
+		contentType string
+	)
+	if rb := route.Operation.RequestBody; rb != nil && rb.Value != nil {
+		var err error
+		contentType, body, err = requestBody(rb.Value)
+		if err != nil && rb.Value.Required {
+			return nil, nil, nil, fmt.Errorf("request body: %w", err)
+		}
+	}
+
+	target := base.String() + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, route.Method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if body == nil {
+		req.Body, req.GetBody, req.ContentLength = http.NoBody, nil, 0
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req, pathParams, body, nil
+}
+
+// parameters returns the parameters of the route's operation, including those inherited from
+// its path item unless the operation overrides them.
+func parameters(route *routers.Route) []*openapi3.Parameter {
+	var params []*openapi3.Parameter
+	seen := make(map[string]bool)
+	for _, list := range []openapi3.Parameters{route.Operation.Parameters, route.PathItem.Parameters} {
+		for _, ref := range list {
+			if ref == nil || ref.Value == nil || seen[ref.Value.In+" "+ref.Value.Name] {
+				continue
+			}
+			seen[ref.Value.In+" "+ref.Value.Name] = true
+			params = append(params, ref.Value)
+		}
+	}
+	return params
+}
+
+// paramValues returns the serialized values of a parameter: several for exploded query arrays,
+// a single one otherwise.
+func paramValues(p *openapi3.Parameter) ([]string, error) {
+	var value any
+	switch {
+	case p.Example != nil:
+		value = p.Example
+	case len(p.Examples) > 0:
+		for _, name := range mapkeys.Sorted(p.Examples) {
+			if example := p.Examples[name]; example != nil && example.Value != nil {
+				value = example.Value.Value
+				break
+			}
+		}
+	case len(p.Content) > 0:
+		// Parameters with content are serialized with their media type, JSON being the only one supported.
+		mediaType, data, err := encodeContent(p.Content, false)
+		if err != nil || mediaType == "" {
+			return nil, fmt.Errorf("%w: unsupported content", errUntestable)
+		}
+		return []string{string(data)}, nil
+	case p.Schema != nil:
+		value = validator.SampleRequestValue(p.Schema.Value)
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return nil, errUntestable
+	case map[string]any:
+		return nil, fmt.Errorf("%w: object parameters are not supported", errUntestable)
+	case []any:
+		values := make([]string, len(value))
+		for i, v := range value {
+			values[i] = fmt.Sprint(v)
+		}
+		if p.In == openapi3.ParameterInQuery && (p.Explode == nil || *p.Explode) && (p.Style == "" || p.Style == openapi3.SerializationForm) {
+			return values, nil
+		}
+		return []string{strings.Join(values, ",")}, nil
+	default:
+		return []string{fmt.Sprint(value)}, nil
+	}
+}
+
+// requestBody returns the content type and encoded body of a request, preferring JSON.
+func requestBody(rb *openapi3.RequestBody) (string, []byte, error) {
+	mediaType, data, err := encodeContent(rb.Content, true)
+	if err != nil {
+		return "", nil, err
+	}
+	if mediaType == "" {
+		return "", nil, fmt.Errorf("%w: no JSON, form or text media type", errUntestable)
+	}
+	return mediaType, data, nil
+}
+
+// encodeContent picks a media type of content, JSON first, then forms and text when allowed,
+// and encodes its example or a synthesized value. It returns an empty media type when none is supported.
+func encodeContent(content openapi3.Content, forms bool) (string, []byte, error) {
+	for _, accept := range []func(string) bool{isJSON, isForm, isText} {
+		for _, mediaType := range mapkeys.Sorted(content) {
+			if !accept(mediaType) || (!forms && !isJSON(mediaType)) {
+				continue
+			}
+			value := mediaValue(content[mediaType])
+			if value == nil {
+				return "", nil, errUntestable
+			}
+			data, err := encode(mediaType, value)
+			return mediaType, data, err
+		}
+	}
+	return "", nil, nil
+}
+
+// mediaValue returns the example of a media type, or a value synthesized from its schema.
+func mediaValue(media *openapi3.MediaType) any {
+	if media == nil {
+		return nil
+	}
+	if media.Example != nil {
+		return media.Example
+	}
+	for _, name := range mapkeys.Sorted(media.Examples) {
+		if example := media.Examples[name]; example != nil && example.Value != nil {
+			return example.Value.Value
+		}
+	}
+	if media.Schema != nil {
+		return validator.SampleRequestValue(media.Schema.Value)
+	}
+	return nil
+}
+
+// encode encodes value for mediaType.
+func encode(mediaType string, value any) ([]byte, error) {
+	switch {
+	case isJSON(mediaType):
+		return json.Marshal(value)
+	case isForm(mediaType):
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: form value is not an object", errUntestable)
+		}
+		form := url.Values{}
+		for _, name := range mapkeys.Sorted(object) {
+			if items, ok := object[name].([]any); ok {
+				for _, item := range items {
+					form.Add(name, fmt.Sprint(item))
+				}
+				continue
+			}
+			form.Set(name, fmt.Sprint(object[name]))
+		}
+		return []byte(form.Encode()), nil
+	default:
+		return []byte(fmt.Sprint(value)), nil
+	}
+}
+
+// baseMediaType strips the parameters of a media type.
+func baseMediaType(mediaType string) string {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+func isJSON(mediaType string) bool {
+	mediaType = baseMediaType(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func isForm(mediaType string) bool {
+	return baseMediaType(mediaType) == "application/x-www-form-urlencoded"
+}
+
+func isText(mediaType string) bool {
+	return strings.HasPrefix(baseMediaType(mediaType), "text/")
+}
+
+// validateRequest checks a generated request against the spec. Security requirements are left
+// to the service, since credentials are added by request editors.
+func validateRequest(ctx context.Context, req *http.Request, route *routers.Route, pathParams map[string]string) error {
+	return openapi3filter.ValidateRequest(ctx, &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			MultiError:          true,
+			SkipSettingDefaults: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+	})
+}
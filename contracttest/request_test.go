@@ -0,0 +1,129 @@
+package contracttest
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// testRoute returns the route of the only operation of a spec with the given paths.
+func testRoute(t *testing.T, method, path, paths string) *routers.Route {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte("openapi: 3.0.0\ninfo: {title: Test API, version: 1.0.0}\npaths:\n" + paths))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	item := doc.Paths.Value(path)
+	return &routers.Route{Spec: doc, Path: path, PathItem: item, Method: method, Operation: item.GetOperation(method)}
+}
+
+func TestNewRequest(t *testing.T) {
+	base, _ := url.Parse("http://localhost:8080/api")
+
+	t.Run("Parameters", func(t *testing.T) {
+		// Arrange
+		route := testRoute(t, "GET", "/pets/{id}", `
+  /pets/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}, example: a b}
+    get:
+      parameters:
+        - {name: tags, in: query, required: true, schema: {type: array, items: {type: string, enum: [x]}, minItems: 2}}
+        - {name: ids, in: query, required: true, explode: false, schema: {type: array, items: {type: integer}}}
+        - {name: skip, in: query, schema: {type: integer}}
+        - {name: X-Version, in: header, required: true, schema: {type: integer, minimum: 3}}
+        - {name: session, in: cookie, required: true, examples: {a: {value: abc}}}
+        - {name: meta, in: query, required: true, content: {application/json: {schema: {type: object, properties: {q: {type: string, example: dogs}}}}}}
+      responses: {'200': {description: OK}}
+`)
+
+		// Act
+		req, pathParams, body, err := newRequest(context.Background(), route, base)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if req.URL.EscapedPath() != "/api/pets/a%20b" || pathParams["id"] != "a b" {
+			t.Errorf("unexpected path %q or path params %v", req.URL.EscapedPath(), pathParams)
+		}
+		query := req.URL.Query()
+		if len(query["tags"]) != 2 || query.Get("ids") != "1" || query.Has("skip") || query.Get("meta") != `{"q":"dogs"}` {
+			t.Errorf("unexpected query: %v", query)
+		}
+		if req.Header.Get("X-Version") != "3" {
+			t.Errorf("expected the minimum header value, got %q", req.Header.Get("X-Version"))
+		}
+		if c, err := req.Cookie("session"); err != nil || c.Value != "abc" {
+			t.Errorf("expected the example cookie, got %v (%v)", c, err)
+		}
+		if body != nil || req.Body == nil {
+			t.Errorf("expected no body, got %q", body)
+		}
+	})
+
+	t.Run("Form Body", func(t *testing.T) {
+		// Arrange
+		route := testRoute(t, "POST", "/login", `
+  /login:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/x-www-form-urlencoded:
+            example: {user: alice, scopes: [read, write]}
+      responses: {'204': {description: OK}}
+`)
+
+		// Act
+		req, _, body, err := newRequest(context.Background(), route, base)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, _ := io.ReadAll(req.Body)
+		if string(body) != "scopes=read&scopes=write&user=alice" || string(data) != string(body) {
+			t.Errorf("unexpected body %q", body)
+		}
+		if req.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Errorf("unexpected content type %q", req.Header.Get("Content-Type"))
+		}
+	})
+
+	t.Run("Untestable", func(t *testing.T) {
+		for name, paths := range map[string]string{
+			"Object Parameter": `
+  /search:
+    get:
+      parameters:
+        - {name: filter, in: query, required: true, schema: {type: object}}
+      responses: {'200': {description: OK}}
+`,
+			"Binary Body": `
+  /search:
+    get:
+      requestBody:
+        required: true
+        content:
+          application/octet-stream: {schema: {type: string, format: binary}}
+      responses: {'200': {description: OK}}
+`,
+		} {
+			// Arrange
+			route := testRoute(t, "GET", "/search", paths)
+
+			// Act
+			_, _, _, err := newRequest(context.Background(), route, base)
+
+			// Assert
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
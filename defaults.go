@@ -0,0 +1,241 @@
+package openapi_validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// applyDefaults rewrites r with the defaults of the operation's missing query parameters and
+// JSON body properties, coercing string body values to the scalar types their schemas declare.
+// Requests whose body cannot be decoded are left untouched for validation to report.
+// A body that cannot be read fails the request, since it has already been consumed.
+func applyDefaults(r *http.Request, route *routers.Route) error {
+	applyQueryDefaults(r, route)
+	return applyBodyDefaults(r, route)
+}
+
+// applyQueryDefaults adds the defaults of query parameters that are absent from the request.
+func applyQueryDefaults(r *http.Request, route *routers.Route) {
+	query := r.URL.Query()
+	changed := false
+	for _, ref := range operationParameters(route) {
+		param := ref.Value
+		if param.In != openapi3.ParameterInQuery || param.Schema == nil || param.Schema.Value == nil {
+			continue
+		}
+		if _, ok := query[param.Name]; ok {
+			continue
+		}
+		def := param.Schema.Value.Default
+		if def == nil {
+			continue
+		}
+
+		switch value := def.(type) {
+		case []any:
+			sm, err := param.SerializationMethod()
+			if err != nil {
+				continue
+			}
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, formatDefault(item))
+			}
+			if sm.Explode {
+				query[param.Name] = items
+			} else {
+				query.Set(param.Name, strings.Join(items, arrayDelimiter(sm.Style)))
+			}
+		case map[string]any:
+			// Object defaults have no unambiguous query serialization.
+			continue
+		default:
+			query.Set(param.Name, formatDefault(value))
+		}
+		changed = true
+	}
+	if changed {
+		r.URL.RawQuery = query.Encode()
+	}
+}
+
+// arrayDelimiter returns the separator of the items of a non-exploded query array in style.
+func arrayDelimiter(style string) string {
+	switch style {
+	case openapi3.SerializationSpaceDelimited:
+		return " "
+	case openapi3.SerializationPipeDelimited:
+		return "|"
+	default:
+		return ","
+	}
+}
+
+// applyBodyDefaults fills in and coerces the JSON request body, restoring r.Body and Content-Length.
+func applyBodyDefaults(r *http.Request, route *routers.Route) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	requestBody := route.Operation.RequestBody
+	if requestBody == nil || requestBody.Value == nil {
+		return nil
+	}
+	contentType := r.Header.Get("Content-Type")
+	if !isJSONMediaType(contentType) {
+		return nil
+	}
+	media := requestBody.Value.Content.Get(contentType)
+	if media == nil || media.Schema == nil || media.Schema.Value == nil {
+		return nil
+	}
+
+	data, err := bufferBody(r)
+	if err != nil {
+		return &openapi3filter.RequestError{RequestBody: requestBody.Value, Reason: "reading failed", Err: err}
+	}
+	restoreBody(r, data)
+	if len(data) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep numbers as written so values that need no rewriting do not lose precision.
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+
+	value, changed := withDefaults(value, media.Schema.Value)
+	if !changed {
+		return nil
+	}
+
+	rewritten, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	restoreBody(r, rewritten)
+	if r.Header.Get("Content-Length") != "" {
+		r.Header.Set("Content-Length", strconv.Itoa(len(rewritten)))
+	}
+	return nil
+}
+
+// withDefaults returns value with schema defaults set for missing object properties and
+// string scalars coerced to the declared type, and whether anything changed.
+func withDefaults(value any, schema *openapi3.Schema) (any, bool) {
+	if schema == nil {
+		return value, false
+	}
+
+	changed := false
+	for _, sub := range schema.AllOf {
+		if sub != nil && sub.Value != nil {
+			var subChanged bool
+			value, subChanged = withDefaults(value, sub.Value)
+			changed = changed || subChanged
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range mapkeys.Sorted(schema.Properties) {
+			prop := schema.Properties[name]
+			if prop == nil || prop.Value == nil {
+				continue
+			}
+			if current, ok := v[name]; ok {
+				if updated, propChanged := withDefaults(current, prop.Value); propChanged {
+					v[name] = updated
+					changed = true
+				}
+				continue
+			}
+			if prop.Value.Default != nil && !prop.Value.ReadOnly {
+				v[name] = prop.Value.Default
+				changed = true
+			}
+		}
+	case []any:
+		if schema.Items != nil && schema.Items.Value != nil {
+			for i, item := range v {
+				if updated, itemChanged := withDefaults(item, schema.Items.Value); itemChanged {
+					v[i] = updated
+					changed = true
+				}
+			}
+		}
+	case string:
+		if coerced, ok := coerceString(v, schema); ok {
+			return coerced, true
+		}
+	}
+	return value, changed
+}
+
+// coerceString converts a string to the integer, number or boolean its schema declares.
+func coerceString(s string, schema *openapi3.Schema) (any, bool) {
+	switch {
+	case schemaIs(schema, openapi3.TypeInteger):
+		if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return json.Number(s), true
+		}
+	case schemaIs(schema, openapi3.TypeNumber):
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return json.Number(s), true
+		}
+	case schemaIs(schema, openapi3.TypeBoolean):
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// operationParameters returns the parameters of the path item and the operation of route.
+// As OpenAPI requires, an operation parameter overrides the path item parameter with the same
+// name and location.
+func operationParameters(route *routers.Route) openapi3.Parameters {
+	var parameters openapi3.Parameters
+	if route.PathItem != nil {
+		for _, ref := range route.PathItem.Parameters {
+			if ref != nil && ref.Value != nil && route.Operation.Parameters.GetByInAndName(ref.Value.In, ref.Value.Name) == nil {
+				parameters = append(parameters, ref)
+			}
+		}
+	}
+	for _, ref := range route.Operation.Parameters {
+		if ref != nil && ref.Value != nil {
+			parameters = append(parameters, ref)
+		}
+	}
+	return parameters
+}
+
+// formatDefault formats a scalar default value for use in a query string.
+func formatDefault(value any) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// isJSONMediaType reports whether contentType is application/json or a +json structured syntax suffix.
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
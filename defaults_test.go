@@ -0,0 +1,275 @@
+package openapi_validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const testDefaultsSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /orders:
+    post:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, default: 20}}
+        - {name: fields, in: query, explode: false, schema: {type: array, items: {type: string}, default: [id, total]}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [quantity]
+              properties:
+                quantity: {type: integer}
+                express: {type: boolean, default: false}
+                currency: {type: string, default: EUR}
+                items:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      gift: {type: boolean, default: false}
+      responses:
+        '200':
+          description: OK
+`
+
+type capturedRequest struct {
+	body          map[string]any
+	query         string
+	contentLength int64
+	header        string
+}
+
+func newDefaultsHandler(t *testing.T, opts ...Option) (http.Handler, *capturedRequest) {
+	t.Helper()
+	v, err := NewFromBytes([]byte(testDefaultsSpec), opts...)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	captured := &capturedRequest{}
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		captured.body = nil
+		json.Unmarshal(data, &captured.body)
+		captured.query = r.URL.RawQuery
+		captured.contentLength = r.ContentLength
+		captured.header = r.Header.Get("Content-Length")
+		if int64(len(data)) != r.ContentLength {
+			t.Errorf("ContentLength %d does not match body length %d", r.ContentLength, len(data))
+		}
+	}))
+	return handler, captured
+}
+
+func newJSONRequest(target, body string) *http.Request {
+	req := httptest.NewRequest("POST", target, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return req
+}
+
+func TestApplyDefaults(t *testing.T) {
+	handler, captured := newDefaultsHandler(t, WithApplyDefaults())
+
+	t.Run("Body And Query Defaults", func(t *testing.T) {
+		// Arrange
+		req := newJSONRequest("/orders", `{"quantity":2,"items":[{},{"gift":true}]}`)
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		want := map[string]any{
+			"quantity": 2.0,
+			"express":  false,
+			"currency": "EUR",
+			"items":    []any{map[string]any{"gift": false}, map[string]any{"gift": true}},
+		}
+		if !reflect.DeepEqual(captured.body, want) {
+			t.Errorf("expected body %v, got %v", want, captured.body)
+		}
+		if captured.query != "fields=id%2Ctotal&limit=20" {
+			t.Errorf("expected query defaults, got %q", captured.query)
+		}
+		if captured.header != strconv.FormatInt(captured.contentLength, 10) {
+			t.Errorf("expected Content-Length header %d, got %q", captured.contentLength, captured.header)
+		}
+	})
+
+	t.Run("Coerces Scalars", func(t *testing.T) {
+		// Arrange
+		req := newJSONRequest("/orders?limit=5", `{"quantity":"3","express":"true","currency":"USD"}`)
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if captured.body["quantity"] != 3.0 || captured.body["express"] != true || captured.body["currency"] != "USD" {
+			t.Errorf("unexpected body %v", captured.body)
+		}
+		if captured.query != "fields=id%2Ctotal&limit=5" {
+			t.Errorf("expected explicit limit to be kept, got %q", captured.query)
+		}
+	})
+
+	t.Run("Invalid Body Left Untouched", func(t *testing.T) {
+		// Arrange
+		req := newJSONRequest("/orders", `{"quantity":"many"}`)
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+}
+
+func TestApplyDefaultsDisabled(t *testing.T) {
+	// Arrange
+	handler, captured := newDefaultsHandler(t)
+	req := newJSONRequest("/orders", `{"quantity":2}`)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !reflect.DeepEqual(captured.body, map[string]any{"quantity": 2.0}) {
+		t.Errorf("expected body to be unchanged, got %v", captured.body)
+	}
+	if captured.query != "" {
+		t.Errorf("expected query to be unchanged, got %q", captured.query)
+	}
+}
+
+func TestApplyDefaults_OperationOverridesPathItem(t *testing.T) {
+	// Arrange
+	v, err := NewFromBytes([]byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /orders:
+    parameters:
+      - {name: sort, in: query, schema: {type: string, default: date}}
+      - {name: page, in: query, schema: {type: integer, default: 1}}
+      - {name: size, in: query, schema: {type: integer, default: 10}}
+    get:
+      parameters:
+        - {name: sort, in: query, schema: {type: string, default: total}}
+        - {name: page, in: query, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+`), WithApplyDefaults())
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	var query string
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+	}))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/orders", nil))
+
+	// Assert
+	if query != "size=10&sort=total" {
+		t.Errorf("expected the operation's parameters to override the path item's, got %q", query)
+	}
+}
+
+func TestApplyDefaults_ArrayStyles(t *testing.T) {
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{style: "form", want: "a,b"},
+		{style: "spaceDelimited", want: "a b"},
+		{style: "pipeDelimited", want: "a|b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.style, func(t *testing.T) {
+			// Arrange
+			v, err := NewFromBytes([]byte(`
+openapi: 3.0.0
+info: {title: Test API, version: 1.0.0}
+paths:
+  /orders:
+    get:
+      parameters:
+        - {name: v, in: query, style: `+tt.style+`, explode: false, schema: {type: array, items: {type: string}, default: [a, b]}}
+      responses:
+        '200': {description: OK}
+`), WithApplyDefaults())
+			if err != nil {
+				t.Fatalf("failed to create validator: %v", err)
+			}
+			var (
+				raw   string
+				items []string
+			)
+			handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				raw = r.URL.Query().Get("v")
+				items, _ = ParamsFromContext(r.Context()).StringSlice("v")
+			}))
+
+			// Act
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/orders", nil))
+
+			// Assert
+			if raw != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, raw)
+			}
+			if !reflect.DeepEqual(items, []string{"a", "b"}) {
+				t.Errorf("expected the default to decode to [a b], got %v", items)
+			}
+		})
+	}
+}
+
+func TestApplyDefaults_UnreadableBody(t *testing.T) {
+	// Arrange
+	handler, _ := newDefaultsHandler(t, WithApplyDefaults())
+	req := httptest.NewRequest("POST", "/orders", io.MultiReader(strings.NewReader(`{"quantity":`), iotest.ErrReader(errors.New("connection reset"))))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rec, req)
+
+	// Assert
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "connection reset") {
+		t.Errorf("expected 400 reporting the read error, got %d %s", rec.Code, rec.Body.String())
+	}
+}
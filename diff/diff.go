@@ -0,0 +1,333 @@
+// Package diff detects changes between two versions of an OpenAPI spec and classifies them as
+// breaking or non-breaking for existing clients. Requests and responses are judged in opposite
+// directions: narrowing what requests may contain breaks clients, and so does widening what
+// responses may contain.
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// Kind classifies a change.
+type Kind string
+
+// The kinds of changes reported by Compare. Whether a change is breaking also depends on
+// whether it affects a request or a response.
+const (
+	OperationRemoved    Kind = "operation-removed"
+	OperationAdded      Kind = "operation-added"
+	ParameterRemoved    Kind = "parameter-removed"
+	ParameterAdded      Kind = "parameter-added"
+	ParameterRequired   Kind = "parameter-required"
+	ParameterOptional   Kind = "parameter-optional"
+	RequestBodyRemoved  Kind = "request-body-removed"
+	RequestBodyAdded    Kind = "request-body-added"
+	RequestBodyRequired Kind = "request-body-required"
+	RequestBodyOptional Kind = "request-body-optional"
+	MediaTypeRemoved    Kind = "media-type-removed"
+	MediaTypeAdded      Kind = "media-type-added"
+	ResponseRemoved     Kind = "response-removed"
+	ResponseAdded       Kind = "response-added"
+	TypeChanged         Kind = "type-changed"
+	FormatChanged       Kind = "format-changed"
+	EnumNarrowed        Kind = "enum-narrowed"
+	EnumWidened         Kind = "enum-widened"
+	PropertyRemoved     Kind = "property-removed"
+	PropertyAdded       Kind = "property-added"
+	PropertyRequired    Kind = "property-required"
+	PropertyOptional    Kind = "property-optional"
+	ConstraintTightened Kind = "constraint-tightened"
+	ConstraintLoosened  Kind = "constraint-loosened"
+)
+
+// Change is a single difference between two versions of a spec.
+type Change struct {
+	Kind Kind `json:"kind"`
+	// Breaking reports whether clients written against the base version may fail against the revision.
+	Breaking bool `json:"breaking"`
+	// Operation is the affected operation, e.g. "GET /pets/{id}".
+	Operation string `json:"operation"`
+	// Location is the affected element of the operation, e.g. "query parameter limit" or
+	// "response 200 application/json /items[]/id". It is empty for operation-level changes.
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+// Report is the list of changes between two versions of a spec, ordered by path and method.
+type Report struct {
+	Changes []Change `json:"changes"`
+}
+
+// Breaking returns the breaking changes of the report.
+func (r *Report) Breaking() []Change {
+	var breaking []Change
+	for _, c := range r.Changes {
+		if c.Breaking {
+			breaking = append(breaking, c)
+		}
+	}
+	return breaking
+}
+
+// direction is the way values flow: requests from clients, responses to clients.
+type direction int
+
+const (
+	request direction = iota
+	response
+)
+
+// breaks reports whether accepting fewer (tightened) or more values breaks clients in direction d.
+func (d direction) breaks(tightened bool) bool {
+	return tightened == (d == request)
+}
+
+// comparer accumulates the changes of the operation being compared.
+type comparer struct {
+	changes   []Change
+	operation string
+	seen      map[[2]*openapi3.Schema]bool
+}
+
+func (c *comparer) add(kind Kind, breaking bool, location, format string, args ...any) {
+	c.changes = append(c.changes, Change{
+		Kind:      kind,
+		Breaking:  breaking,
+		Operation: c.operation,
+		Location:  location,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// Compare returns the changes from base to revision. Both documents should be loaded and
+// validated the way the validator does, e.g. through Validator.Spec.
+func Compare(base, revision *openapi3.T) *Report {
+	c := &comparer{seen: make(map[[2]*openapi3.Schema]bool)}
+	baseOps, revOps := operations(base), operations(revision)
+
+	keys := make(map[string]bool)
+	for key := range baseOps {
+		keys[key] = true
+	}
+	for key := range revOps {
+		keys[key] = true
+	}
+
+	for _, key := range mapkeys.Sorted(keys) {
+		b, r := baseOps[key], revOps[key]
+		switch {
+		case r == nil:
+			c.operation = b.name()
+			c.add(OperationRemoved, true, "", "operation was removed")
+		case b == nil:
+			c.operation = r.name()
+			c.add(OperationAdded, false, "", "operation was added")
+		default:
+			c.operation = r.name()
+			c.compareOperation(b, r)
+		}
+	}
+	return &Report{Changes: c.changes}
+}
+
+// operation is an operation of a spec with the parameters it inherits from its path item.
+type operation struct {
+	method, path string
+	op           *openapi3.Operation
+	params       map[string]*openapi3.Parameter
+}
+
+func (o *operation) name() string {
+	return o.method + " " + o.path
+}
+
+// templateParamRE matches the parameters of a path template.
+var templateParamRE = regexp.MustCompile(`\{[^}]*\}`)
+
+// operations returns the operations of doc keyed by path template, without parameter names so
+// that renaming a path parameter does not read as a removal, and method.
+func operations(doc *openapi3.T) map[string]*operation {
+	ops := make(map[string]*operation)
+	if doc == nil || doc.Paths == nil {
+		return ops
+	}
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			params := make(map[string]*openapi3.Parameter)
+			for _, list := range []openapi3.Parameters{item.Parameters, op.Parameters} {
+				for _, ref := range list {
+					if ref == nil || ref.Value == nil {
+						continue
+					}
+					params[paramKey(ref.Value)] = ref.Value
+				}
+			}
+			key := templateParamRE.ReplaceAllString(path, "{}") + " " + method
+			ops[key] = &operation{method: method, path: path, op: op, params: params}
+		}
+	}
+	return ops
+}
+
+// paramKey identifies a parameter within an operation. Path parameters are identified by
+// position so that renaming them is not a change.
+func paramKey(p *openapi3.Parameter) string {
+	name := p.Name
+	if p.In == openapi3.ParameterInHeader {
+		name = strings.ToLower(name)
+	}
+	return p.In + " " + name
+}
+
+func (c *comparer) compareOperation(b, r *operation) {
+	c.compareParameters(b, r)
+	c.compareRequestBody(b.op.RequestBody, r.op.RequestBody)
+	c.compareResponses(b.op.Responses, r.op.Responses)
+}
+
+func (c *comparer) compareParameters(b, r *operation) {
+	// Path parameters are matched by position, as the path template is.
+	bp, rp := withPathParamsByPosition(b), withPathParamsByPosition(r)
+
+	keys := make(map[string]bool)
+	for key := range bp {
+		keys[key] = true
+	}
+	for key := range rp {
+		keys[key] = true
+	}
+
+	for _, key := range mapkeys.Sorted(keys) {
+		bParam, rParam := bp[key], rp[key]
+		switch {
+		case rParam == nil:
+			c.add(ParameterRemoved, false, paramLocation(bParam), "parameter was removed")
+		case bParam == nil:
+			if rParam.Required {
+				c.add(ParameterAdded, true, paramLocation(rParam), "required parameter was added")
+			} else {
+				c.add(ParameterAdded, false, paramLocation(rParam), "optional parameter was added")
+			}
+		default:
+			location := paramLocation(rParam)
+			if !bParam.Required && rParam.Required {
+				c.add(ParameterRequired, true, location, "parameter became required")
+			} else if bParam.Required && !rParam.Required {
+				c.add(ParameterOptional, false, location, "parameter became optional")
+			}
+			c.compareSchema(location, "", bParam.Schema, rParam.Schema, request)
+			c.compareContent(location, bParam.Content, rParam.Content, request)
+		}
+	}
+}
+
+// withPathParamsByPosition returns the parameters of o with path parameters keyed by their
+// position in the path template.
+func withPathParamsByPosition(o *operation) map[string]*openapi3.Parameter {
+	params := make(map[string]*openapi3.Parameter, len(o.params))
+	for key, p := range o.params {
+		if p.In != openapi3.ParameterInPath {
+			params[key] = p
+		}
+	}
+	for i, name := range templateParamRE.FindAllString(o.path, -1) {
+		if p := o.params[paramKey(&openapi3.Parameter{In: openapi3.ParameterInPath, Name: strings.Trim(name, "{}")})]; p != nil {
+			params[fmt.Sprintf("path #%d", i)] = p
+		}
+	}
+	return params
+}
+
+func paramLocation(p *openapi3.Parameter) string {
+	return p.In + " parameter " + p.Name
+}
+
+func (c *comparer) compareRequestBody(bRef, rRef *openapi3.RequestBodyRef) {
+	var b, r *openapi3.RequestBody
+	if bRef != nil {
+		b = bRef.Value
+	}
+	if rRef != nil {
+		r = rRef.Value
+	}
+
+	switch {
+	case b == nil && r == nil:
+	case r == nil:
+		c.add(RequestBodyRemoved, false, "request body", "request body was removed")
+	case b == nil:
+		if r.Required {
+			c.add(RequestBodyAdded, true, "request body", "required request body was added")
+		} else {
+			c.add(RequestBodyAdded, false, "request body", "optional request body was added")
+		}
+	default:
+		if !b.Required && r.Required {
+			c.add(RequestBodyRequired, true, "request body", "request body became required")
+		} else if b.Required && !r.Required {
+			c.add(RequestBodyOptional, false, "request body", "request body became optional")
+		}
+		c.compareContent("request body", b.Content, r.Content, request)
+	}
+}
+
+func (c *comparer) compareResponses(b, r *openapi3.Responses) {
+	bMap, rMap := map[string]*openapi3.ResponseRef{}, map[string]*openapi3.ResponseRef{}
+	if b != nil {
+		bMap = b.Map()
+	}
+	if r != nil {
+		rMap = r.Map()
+	}
+
+	keys := make(map[string]bool)
+	for code := range bMap {
+		keys[code] = true
+	}
+	for code := range rMap {
+		keys[code] = true
+	}
+
+	for _, code := range mapkeys.Sorted(keys) {
+		location := "response " + code
+		bResp, rResp := bMap[code], rMap[code]
+		switch {
+		case rResp == nil:
+			c.add(ResponseRemoved, true, location, "response was removed")
+		case bResp == nil:
+			c.add(ResponseAdded, false, location, "response was added")
+		case bResp.Value != nil && rResp.Value != nil:
+			c.compareContent(location, bResp.Value.Content, rResp.Value.Content, response)
+		}
+	}
+}
+
+// compareContent compares the media types of a request body, response or parameter.
+// Removing a media type breaks clients in both directions: requests using it are rejected and
+// clients expecting it in responses no longer get it.
+func (c *comparer) compareContent(location string, b, r openapi3.Content, dir direction) {
+	keys := make(map[string]bool)
+	for mediaType := range b {
+		keys[mediaType] = true
+	}
+	for mediaType := range r {
+		keys[mediaType] = true
+	}
+
+	for _, mediaType := range mapkeys.Sorted(keys) {
+		bMT, rMT := b[mediaType], r[mediaType]
+		switch {
+		case rMT == nil:
+			c.add(MediaTypeRemoved, true, location, "media type %s was removed", mediaType)
+		case bMT == nil:
+			c.add(MediaTypeAdded, false, location, "media type %s was added", mediaType)
+		default:
+			c.compareSchema(location+" "+mediaType, "", bMT.Schema, rMT.Schema, dir)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package diff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// load parses and validates a spec.
+func load(t *testing.T, spec string) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+	return doc
+}
+
+// spec wraps paths in a minimal document.
+func spec(paths string) string {
+	return "openapi: 3.0.0\ninfo: {title: Test API, version: 1.0.0}\npaths:\n" + paths
+}
+
+// find returns the changes of kind at location.
+func find(report *Report, kind Kind, location string) []Change {
+	var found []Change
+	for _, c := range report.Changes {
+		if c.Kind == kind && c.Location == location {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+func TestCompare_Operations(t *testing.T) {
+	// Arrange
+	base := load(t, spec(`
+  /pets/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses: {'200': {description: OK}}
+    delete:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses: {'204': {description: Deleted}}
+`))
+	revision := load(t, spec(`
+  /pets/{petId}:
+    get:
+      parameters:
+        - {name: petId, in: path, required: true, schema: {type: integer}}
+      responses: {'200': {description: OK}}
+  /owners:
+    get:
+      responses: {'200': {description: OK}}
+`))
+
+	// Act
+	report := Compare(base, revision)
+
+	// Assert
+	if len(report.Changes) != 2 {
+		t.Fatalf("expected two changes, got %+v", report.Changes)
+	}
+	if c := report.Changes[0]; c.Kind != OperationAdded || c.Breaking || c.Operation != "GET /owners" {
+		t.Errorf("expected GET /owners to be added, got %+v", c)
+	}
+	if c := report.Changes[1]; c.Kind != OperationRemoved || !c.Breaking || c.Operation != "DELETE /pets/{id}" {
+		t.Errorf("expected DELETE /pets/{id} to be removed, got %+v", c)
+	}
+}
+
+func TestCompare_Parameters(t *testing.T) {
+	// Arrange
+	base := load(t, spec(`
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, maximum: 100}}
+        - {name: sort, in: query, schema: {type: string, enum: [name, age]}}
+        - {name: X-Trace, in: header, schema: {type: string}}
+      responses: {'200': {description: OK}}
+`))
+	revision := load(t, spec(`
+  /pets:
+    get:
+      parameters:
+        - {name: limit, in: query, required: true, schema: {type: integer, maximum: 50}}
+        - {name: sort, in: query, schema: {type: string, enum: [name, age, id]}}
+        - {name: owner, in: query, required: true, schema: {type: string}}
+        - {name: page, in: query, schema: {type: integer}}
+      responses: {'200': {description: OK}}
+`))
+
+	// Act
+	report := Compare(base, revision)
+
+	// Assert
+	tests := []struct {
+		kind     Kind
+		location string
+		breaking bool
+	}{
+		{ParameterRequired, "query parameter limit", true},
+		{ConstraintTightened, "query parameter limit", true},
+		{EnumWidened, "query parameter sort", false},
+		{ParameterAdded, "query parameter owner", true},
+		{ParameterAdded, "query parameter page", false},
+		{ParameterRemoved, "header parameter X-Trace", false},
+	}
+	for _, tt := range tests {
+		found := find(report, tt.kind, tt.location)
+		if len(found) != 1 || found[0].Breaking != tt.breaking {
+			t.Errorf("expected one %s change at %s (breaking %v), got %+v", tt.kind, tt.location, tt.breaking, found)
+		}
+	}
+	if len(report.Changes) != len(tests) {
+		t.Errorf("expected %d changes, got %+v", len(tests), report.Changes)
+	}
+}
+
+func TestCompare_RequestBodyAndResponses(t *testing.T) {
+	// Arrange
+	base := load(t, spec(`
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object, properties: {name: {type: string}}}
+          application/xml:
+            schema: {type: object}
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema: {type: object, required: [id], properties: {id: {type: integer}, name: {type: string}}}
+        '409': {description: Conflict}
+`))
+	revision := load(t, spec(`
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {type: object, required: [name], properties: {name: {type: string}}}
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema: {type: object, properties: {id: {type: string}}}
+        '422': {description: Unprocessable}
+`))
+
+	// Act
+	report := Compare(base, revision)
+
+	// Assert
+	tests := []struct {
+		kind     Kind
+		location string
+		breaking bool
+	}{
+		{RequestBodyRequired, "request body", true},
+		{MediaTypeRemoved, "request body", true},
+		{PropertyRequired, "request body application/json", true},
+		{PropertyOptional, "response 201 application/json", true},
+		{PropertyRemoved, "response 201 application/json", true},
+		{TypeChanged, "response 201 application/json /id", true},
+		{ResponseRemoved, "response 409", true},
+		{ResponseAdded, "response 422", false},
+	}
+	for _, tt := range tests {
+		found := find(report, tt.kind, tt.location)
+		if len(found) != 1 || found[0].Breaking != tt.breaking {
+			t.Errorf("expected one %s change at %s (breaking %v), got %+v", tt.kind, tt.location, tt.breaking, found)
+		}
+	}
+	if len(report.Changes) != len(tests) {
+		t.Errorf("expected %d changes, got %+v", len(tests), report.Changes)
+	}
+}
+
+func TestCompare_SharedSchema(t *testing.T) {
+	// Arrange
+	doc := func(required string) string {
+		return spec(`
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses: {'201': {description: Created}}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [` + required + `]
+      properties:
+        name: {type: string}
+        tag: {type: string}
+        parent: {$ref: '#/components/schemas/Pet'}
+`)
+	}
+
+	// Act
+	report := Compare(load(t, doc("name")), load(t, doc("name, tag")))
+
+	// Assert
+	if found := find(report, PropertyRequired, "request body application/json"); len(found) != 1 || !found[0].Breaking || found[0].Operation != "POST /pets" {
+		t.Errorf("expected the shared schema to break the POST request body, got %+v", report.Changes)
+	}
+	if found := find(report, PropertyRequired, "response 200 application/json"); len(found) != 1 || found[0].Breaking {
+		t.Errorf("expected a non-breaking change to the GET response, got %+v", report.Changes)
+	}
+	if len(report.Breaking()) != 1 {
+		t.Errorf("expected one breaking change, got %+v", report.Changes)
+	}
+}
+
+func TestCompare_Identical(t *testing.T) {
+	// Arrange
+	doc := spec(`
+  /pets:
+    get:
+      responses: {'200': {description: OK}}
+`)
+
+	// Act
+	report := Compare(load(t, doc), load(t, doc))
+
+	// Assert
+	if len(report.Changes) != 0 || len(report.Breaking()) != 0 {
+		t.Errorf("expected no changes, got %+v", report.Changes)
+	}
+}
@@ -0,0 +1,68 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes the report as Markdown tables, breaking changes first, for pull request comments.
+func WriteMarkdown(w io.Writer, report *Report) error {
+	var b strings.Builder
+	b.WriteString("## API changes\n\n")
+	if len(report.Changes) == 0 {
+		b.WriteString("No API changes.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	breaking := report.Breaking()
+	fmt.Fprintf(&b, "**%d breaking**, %d non-breaking changes.\n", len(breaking), len(report.Changes)-len(breaking))
+
+	section := func(title string, breaking bool) {
+		var rows []Change
+		for _, c := range report.Changes {
+			if c.Breaking == breaking {
+				rows = append(rows, c)
+			}
+		}
+		if len(rows) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", title)
+		b.WriteString("| Operation | Location | Change |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for _, c := range rows {
+			location := ""
+			if c.Location != "" {
+				location = "`" + c.Location + "`"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", c.Operation, location, markdownEscape(c.Message))
+		}
+	}
+	section("Breaking", true)
+	section("Non-breaking", false)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEscape escapes the characters that would break a Markdown table cell.
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// WriteJSON writes the report as JSON, with the number of breaking changes for quick checks.
+func WriteJSON(w io.Writer, report *Report) error {
+	changes := report.Changes
+	if changes == nil {
+		changes = []Change{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Breaking int      `json:"breaking"`
+		Changes  []Change `json:"changes"`
+	}{len(report.Breaking()), changes})
+}
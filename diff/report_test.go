@@ -0,0 +1,79 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testReport = &Report{Changes: []Change{
+	{Kind: ParameterAdded, Breaking: true, Operation: "GET /pets", Location: "query parameter owner", Message: "required parameter was added"},
+	{Kind: ConstraintTightened, Breaking: true, Operation: "GET /pets", Location: "query parameter q", Message: "pattern ^(a|b)$ was added"},
+	{Kind: OperationAdded, Operation: "GET /owners", Message: "operation was added"},
+}}
+
+func TestWriteMarkdown(t *testing.T) {
+	t.Run("Changes", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		err := WriteMarkdown(&buf, testReport)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, part := range []string{
+			"**2 breaking**, 1 non-breaking changes.",
+			"### Breaking\n\n| Operation | Location | Change |\n| --- | --- | --- |\n| `GET /pets` | `query parameter owner` | required parameter was added |\n",
+			`pattern ^(a\|b)$ was added`,
+			"### Non-breaking\n\n| Operation | Location | Change |\n| --- | --- | --- |\n| `GET /owners` |  | operation was added |\n",
+		} {
+			if !strings.Contains(out, part) {
+				t.Errorf("expected the output to contain %q, got:\n%s", part, out)
+			}
+		}
+		if strings.Index(out, "### Breaking") > strings.Index(out, "### Non-breaking") {
+			t.Error("expected breaking changes to come first")
+		}
+	})
+
+	t.Run("No Changes", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		WriteMarkdown(&buf, &Report{})
+
+		// Assert
+		if !strings.Contains(buf.String(), "No API changes.") {
+			t.Errorf("unexpected output: %q", buf.String())
+		}
+	})
+}
+
+func TestWriteJSON(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := WriteJSON(&buf, testReport)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded struct {
+		Breaking int      `json:"breaking"`
+		Changes  []Change `json:"changes"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+	if decoded.Breaking != 2 || len(decoded.Changes) != 3 || decoded.Changes[0] != testReport.Changes[0] {
+		t.Errorf("expected the report to round-trip, got %+v", decoded)
+	}
+}
@@ -0,0 +1,298 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// compareSchema compares the schemas of the value at ptr below location, flowing in direction dir.
+func (c *comparer) compareSchema(location, ptr string, bRef, rRef *openapi3.SchemaRef, dir direction) {
+	at := strings.TrimSpace(location + " " + ptr)
+
+	var b, r *openapi3.Schema
+	if bRef != nil {
+		b = bRef.Value
+	}
+	if rRef != nil {
+		r = rRef.Value
+	}
+	switch {
+	case b == nil && r == nil:
+		return
+	case b == nil:
+		c.add(ConstraintTightened, dir.breaks(true), at, "schema was added")
+		return
+	case r == nil:
+		c.add(ConstraintLoosened, dir.breaks(false), at, "schema was removed")
+		return
+	}
+
+	// Recursive schemas stop at the pair already being compared further up. Pairs are tracked
+	// along the current path only, so a schema shared by several operations or by requests and
+	// responses is compared at every place it is used.
+	pair := [2]*openapi3.Schema{b, r}
+	if c.seen[pair] {
+		return
+	}
+	c.seen[pair] = true
+	defer delete(c.seen, pair)
+
+	c.compareTypes(at, b, r, dir)
+	c.compareEnums(at, b.Enum, r.Enum, dir)
+	c.compareConstraints(at, b, r, dir)
+	c.compareProperties(location, ptr, b, r, dir)
+	c.compareItems(location, ptr, b, r, dir)
+	c.compareComposition(location, ptr, "allOf", b.AllOf, r.AllOf, true, dir)
+	c.compareComposition(location, ptr, "anyOf", b.AnyOf, r.AnyOf, false, dir)
+	c.compareComposition(location, ptr, "oneOf", b.OneOf, r.OneOf, false, dir)
+}
+
+func (c *comparer) compareTypes(at string, b, r *openapi3.Schema, dir direction) {
+	bTypes, rTypes := b.Type.Slice(), r.Type.Slice()
+	removed, added := difference(bTypes, rTypes), difference(rTypes, bTypes)
+	switch {
+	case len(removed) == 0 && len(added) == 0:
+	case len(bTypes) == 0:
+		// No type means any type, so declaring one narrows the schema.
+		c.add(TypeChanged, dir.breaks(true), at, "type was restricted to %s", strings.Join(rTypes, ", "))
+	case len(rTypes) == 0:
+		c.add(TypeChanged, dir.breaks(false), at, "type restriction %s was removed", strings.Join(bTypes, ", "))
+	case len(added) == 0:
+		c.add(TypeChanged, dir.breaks(true), at, "type %s is no longer allowed", strings.Join(removed, ", "))
+	case len(removed) == 0:
+		c.add(TypeChanged, dir.breaks(false), at, "type %s is now allowed", strings.Join(added, ", "))
+	default:
+		c.add(TypeChanged, true, at, "type changed from %s to %s", strings.Join(bTypes, ", "), strings.Join(rTypes, ", "))
+	}
+
+	switch {
+	case b.Format == r.Format:
+	case b.Format == "":
+		c.add(FormatChanged, dir.breaks(true), at, "format %s was added", r.Format)
+	case r.Format == "":
+		c.add(FormatChanged, dir.breaks(false), at, "format %s was removed", b.Format)
+	default:
+		c.add(FormatChanged, true, at, "format changed from %s to %s", b.Format, r.Format)
+	}
+
+	if b.Nullable && !r.Nullable {
+		c.add(ConstraintTightened, dir.breaks(true), at, "value is no longer nullable")
+	} else if !b.Nullable && r.Nullable {
+		c.add(ConstraintLoosened, dir.breaks(false), at, "value became nullable")
+	}
+}
+
+func (c *comparer) compareEnums(at string, b, r []any, dir direction) {
+	bValues, rValues := enumValues(b), enumValues(r)
+	switch {
+	case len(bValues) == 0 && len(rValues) == 0:
+	case len(bValues) == 0:
+		c.add(EnumNarrowed, dir.breaks(true), at, "values were restricted to %s", strings.Join(rValues, ", "))
+	case len(rValues) == 0:
+		c.add(EnumWidened, dir.breaks(false), at, "enum %s was removed", strings.Join(bValues, ", "))
+	default:
+		if removed := difference(bValues, rValues); len(removed) > 0 {
+			c.add(EnumNarrowed, dir.breaks(true), at, "enum values %s were removed", strings.Join(removed, ", "))
+		}
+		if added := difference(rValues, bValues); len(added) > 0 {
+			c.add(EnumWidened, dir.breaks(false), at, "enum values %s were added", strings.Join(added, ", "))
+		}
+	}
+}
+
+// enumValues returns the enum values in their JSON form, so they compare by value.
+func enumValues(enum []any) []string {
+	values := make([]string, 0, len(enum))
+	for _, v := range enum {
+		data, err := json.Marshal(v)
+		if err != nil {
+			data = []byte(fmt.Sprint(v))
+		}
+		values = append(values, string(data))
+	}
+	return values
+}
+
+func (c *comparer) compareConstraints(at string, b, r *openapi3.Schema, dir direction) {
+	// changed reports a constraint change; tightened means fewer values are accepted.
+	changed := func(tightened bool, format string, args ...any) {
+		c.add(kindOf(tightened), dir.breaks(tightened), at, format, args...)
+	}
+
+	lowerBound := func(name string, b, r *float64) {
+		switch {
+		case sameFloat(b, r):
+		case b == nil:
+			changed(true, "%s %v was added", name, *r)
+		case r == nil:
+			changed(false, "%s %v was removed", name, *b)
+		default:
+			changed(*r > *b, "%s changed from %v to %v", name, *b, *r)
+		}
+	}
+	upperBound := func(name string, b, r *float64) {
+		switch {
+		case sameFloat(b, r):
+		case b == nil:
+			changed(true, "%s %v was added", name, *r)
+		case r == nil:
+			changed(false, "%s %v was removed", name, *b)
+		default:
+			changed(*r < *b, "%s changed from %v to %v", name, *b, *r)
+		}
+	}
+	flag := func(name string, b, r bool) {
+		if b != r {
+			changed(r, "%s changed from %v to %v", name, b, r)
+		}
+	}
+
+	lowerBound("minimum", b.Min, r.Min)
+	upperBound("maximum", b.Max, r.Max)
+	flag("exclusiveMinimum", b.ExclusiveMin, r.ExclusiveMin)
+	flag("exclusiveMaximum", b.ExclusiveMax, r.ExclusiveMax)
+	lowerBound("minLength", count(b.MinLength), count(r.MinLength))
+	upperBound("maxLength", countPtr(b.MaxLength), countPtr(r.MaxLength))
+	lowerBound("minItems", count(b.MinItems), count(r.MinItems))
+	upperBound("maxItems", countPtr(b.MaxItems), countPtr(r.MaxItems))
+	lowerBound("minProperties", count(b.MinProps), count(r.MinProps))
+	upperBound("maxProperties", countPtr(b.MaxProps), countPtr(r.MaxProps))
+	flag("uniqueItems", b.UniqueItems, r.UniqueItems)
+
+	switch {
+	case sameFloat(b.MultipleOf, r.MultipleOf):
+	case r.MultipleOf == nil:
+		changed(false, "multipleOf %v was removed", *b.MultipleOf)
+	case b.MultipleOf == nil:
+		changed(true, "multipleOf %v was added", *r.MultipleOf)
+	default:
+		// Only a divisor of the previous value accepts every value accepted before.
+		ratio := *b.MultipleOf / *r.MultipleOf
+		changed(ratio != float64(int64(ratio)), "multipleOf changed from %v to %v", *b.MultipleOf, *r.MultipleOf)
+	}
+
+	switch {
+	case b.Pattern == r.Pattern:
+	case b.Pattern == "":
+		changed(true, "pattern %s was added", r.Pattern)
+	case r.Pattern == "":
+		changed(false, "pattern %s was removed", b.Pattern)
+	default:
+		// Patterns cannot be compared in general, so assume the worst.
+		c.add(ConstraintTightened, true, at, "pattern changed from %s to %s", b.Pattern, r.Pattern)
+	}
+
+	bAdditional, rAdditional := additionalAllowed(b), additionalAllowed(r)
+	if bAdditional != rAdditional {
+		changed(!rAdditional, "additionalProperties changed from %v to %v", bAdditional, rAdditional)
+	}
+}
+
+// additionalAllowed reports whether s allows properties it does not declare.
+func additionalAllowed(s *openapi3.Schema) bool {
+	return s.AdditionalProperties.Has == nil || *s.AdditionalProperties.Has
+}
+
+func (c *comparer) compareProperties(location, ptr string, b, r *openapi3.Schema, dir direction) {
+	at := strings.TrimSpace(location + " " + ptr)
+
+	for _, name := range difference(r.Required, b.Required) {
+		// New required request properties break clients; in responses they are a new guarantee.
+		c.add(PropertyRequired, dir == request, at, "property %s became required", name)
+	}
+	for _, name := range difference(b.Required, r.Required) {
+		c.add(PropertyOptional, dir == response, at, "property %s is no longer required", name)
+	}
+
+	keys := make(map[string]bool)
+	for name := range b.Properties {
+		keys[name] = true
+	}
+	for name := range r.Properties {
+		keys[name] = true
+	}
+	for _, name := range mapkeys.Sorted(keys) {
+		bProp, rProp := b.Properties[name], r.Properties[name]
+		switch {
+		case rProp == nil:
+			// Clients may read any response property, while servers ignore unknown request properties.
+			c.add(PropertyRemoved, dir == response, at, "property %s was removed", name)
+		case bProp == nil:
+			c.add(PropertyAdded, false, at, "property %s was added", name)
+		default:
+			c.compareSchema(location, ptr+"/"+name, bProp, rProp, dir)
+		}
+	}
+}
+
+func (c *comparer) compareItems(location, ptr string, b, r *openapi3.Schema, dir direction) {
+	if b.Items != nil || r.Items != nil {
+		c.compareSchema(location, ptr+"[]", b.Items, r.Items, dir)
+	}
+}
+
+// compareComposition compares the subschemas of allOf (all must match, so adding one tightens
+// the schema) or anyOf and oneOf (one must match, so adding one loosens it) by position.
+func (c *comparer) compareComposition(location, ptr, keyword string, b, r openapi3.SchemaRefs, all bool, dir direction) {
+	at := strings.TrimSpace(location + " " + ptr)
+	for i := 0; i < len(b) && i < len(r); i++ {
+		c.compareSchema(location, fmt.Sprintf("%s/%s/%d", ptr, keyword, i), b[i], r[i], dir)
+	}
+	if len(r) > len(b) {
+		tightened := all
+		c.add(kindOf(tightened), dir.breaks(tightened), at, "%d %s subschemas were added", len(r)-len(b), keyword)
+	} else if len(b) > len(r) {
+		tightened := !all
+		c.add(kindOf(tightened), dir.breaks(tightened), at, "%d %s subschemas were removed", len(b)-len(r), keyword)
+	}
+}
+
+// kindOf returns the Kind of a constraint change.
+func kindOf(tightened bool) Kind {
+	if tightened {
+		return ConstraintTightened
+	}
+	return ConstraintLoosened
+}
+
+// count returns n as a bound, nil when zero since zero is the default of minimum counts.
+func count(n uint64) *float64 {
+	if n == 0 {
+		return nil
+	}
+	f := float64(n)
+	return &f
+}
+
+// countPtr returns n as a bound.
+func countPtr(n *uint64) *float64 {
+	if n == nil {
+		return nil
+	}
+	f := float64(*n)
+	return &f
+}
+
+// sameFloat reports whether two optional bounds are equal.
+func sameFloat(a, b *float64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+// difference returns the values of a missing from b, in the order of a.
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	var diff []string
+	for _, v := range a {
+		if !in[v] {
+			diff = append(diff, v)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestCompareSchema(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	u := func(v uint64) *uint64 { return &v }
+
+	tests := []struct {
+		name     string
+		base     *openapi3.Schema
+		revision *openapi3.Schema
+		kind     Kind
+		// breaking in requests and in responses
+		request, response bool
+	}{
+		{"Type Changed", &openapi3.Schema{Type: &openapi3.Types{"integer"}}, &openapi3.Schema{Type: &openapi3.Types{"string"}}, TypeChanged, true, true},
+		{"Type Widened", &openapi3.Schema{Type: &openapi3.Types{"string"}}, &openapi3.Schema{Type: &openapi3.Types{"string", "null"}}, TypeChanged, false, true},
+		{"Format Changed", &openapi3.Schema{Format: "date"}, &openapi3.Schema{Format: "date-time"}, FormatChanged, true, true},
+		{"Enum Narrowed", &openapi3.Schema{Enum: []any{"a", "b"}}, &openapi3.Schema{Enum: []any{"a"}}, EnumNarrowed, true, false},
+		{"Enum Widened", &openapi3.Schema{Enum: []any{"a"}}, &openapi3.Schema{Enum: []any{"a", "b"}}, EnumWidened, false, true},
+		{"Enum Added", &openapi3.Schema{}, &openapi3.Schema{Enum: []any{"a"}}, EnumNarrowed, true, false},
+		{"Maximum Lowered", &openapi3.Schema{Max: f(10)}, &openapi3.Schema{Max: f(5)}, ConstraintTightened, true, false},
+		{"Minimum Lowered", &openapi3.Schema{Min: f(10)}, &openapi3.Schema{Min: f(5)}, ConstraintLoosened, false, true},
+		{"MaxLength Added", &openapi3.Schema{}, &openapi3.Schema{MaxLength: u(8)}, ConstraintTightened, true, false},
+		{"MinItems Removed", &openapi3.Schema{MinItems: 1}, &openapi3.Schema{}, ConstraintLoosened, false, true},
+		{"Pattern Changed", &openapi3.Schema{Pattern: "^a"}, &openapi3.Schema{Pattern: "^b"}, ConstraintTightened, true, true},
+		{"MultipleOf Divisor", &openapi3.Schema{MultipleOf: f(4)}, &openapi3.Schema{MultipleOf: f(2)}, ConstraintLoosened, false, true},
+		{"No Longer Nullable", &openapi3.Schema{Nullable: true}, &openapi3.Schema{}, ConstraintTightened, true, false},
+		{"AnyOf Alternative Added", &openapi3.Schema{AnyOf: openapi3.SchemaRefs{openapi3.NewSchemaRef("", openapi3.NewStringSchema())}}, &openapi3.Schema{AnyOf: openapi3.SchemaRefs{openapi3.NewSchemaRef("", openapi3.NewStringSchema()), openapi3.NewSchemaRef("", openapi3.NewIntegerSchema())}}, ConstraintLoosened, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, dir := range []direction{request, response} {
+				// Arrange
+				c := &comparer{seen: make(map[[2]*openapi3.Schema]bool)}
+
+				// Act
+				c.compareSchema("body", "", openapi3.NewSchemaRef("", tt.base), openapi3.NewSchemaRef("", tt.revision), dir)
+
+				// Assert
+				breaking := tt.request
+				if dir == response {
+					breaking = tt.response
+				}
+				if len(c.changes) != 1 || c.changes[0].Kind != tt.kind || c.changes[0].Breaking != breaking {
+					t.Errorf("direction %d: expected one %s change (breaking %v), got %+v", dir, tt.kind, breaking, c.changes)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareSchema_Nested(t *testing.T) {
+	// Arrange
+	base := openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().WithProperty("id", openapi3.NewIntegerSchema()))
+	revision := openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().WithProperty("id", openapi3.NewStringSchema()))
+	c := &comparer{seen: make(map[[2]*openapi3.Schema]bool)}
+
+	// Act
+	c.compareSchema("response 200 application/json", "", openapi3.NewSchemaRef("", base), openapi3.NewSchemaRef("", revision), response)
+
+	// Assert
+	if len(c.changes) != 1 || c.changes[0].Location != "response 200 application/json []/id" {
+		t.Errorf("expected a change at []/id, got %+v", c.changes)
+	}
+}
+
+func TestCompareSchema_Recursive(t *testing.T) {
+	// Arrange
+	node := openapi3.NewObjectSchema()
+	node.WithProperty("children", openapi3.NewArraySchema())
+	node.Properties["children"].Value.Items = openapi3.NewSchemaRef("#/components/schemas/Node", node)
+	c := &comparer{seen: make(map[[2]*openapi3.Schema]bool)}
+
+	// Act
+	c.compareSchema("body", "", openapi3.NewSchemaRef("", node), openapi3.NewSchemaRef("", node), request)
+
+	// Assert
+	if len(c.changes) != 0 {
+		t.Errorf("expected no changes, got %+v", c.changes)
+	}
+}
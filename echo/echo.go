@@ -0,0 +1,92 @@
+// Package echo adapts the OpenAPI validator to Echo. Operations are matched by the Echo route
+// (c.Path()), and failures are returned as *echo.HTTPError so they reach the application's
+// HTTPErrorHandler like any other handler error.
+package echo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/routers"
+	echopkg "github.com/labstack/echo/v4"
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+// Middleware returns an echo.MiddlewareFunc validating requests, and responses when
+// ValidateResponses is enabled, against the operation matching the Echo route. It can be
+// registered with Echo.Use or attached to individual groups and routes.
+//
+// Invalid requests are rejected with an *echo.HTTPError carrying the mapped status, a
+// validator.ValidationError message and the *validator.HTTPError as its internal error.
+// Decoded parameters are available through validator.ParamsFromContext(c.Request().Context()).
+func Middleware(v *validator.Validator, opts ...validator.AdapterOption) echopkg.MiddlewareFunc {
+	cfg := validator.NewAdapterOptions(opts...)
+
+	return func(next echopkg.HandlerFunc) echopkg.HandlerFunc {
+		return func(c echopkg.Context) error {
+			req := c.Request()
+			routePath := c.Path()
+			if routePath == "" || strings.HasPrefix(req.URL.Path, v.Options.SwaggerUIPath) {
+				return next(c)
+			}
+
+			path := cfg.OperationPath(routePath)
+			route, err := v.OperationRoute(req.Method, path)
+			if err != nil {
+				rejection := v.UnmatchedRoute(req, err)
+				if rejection == nil {
+					return next(c)
+				}
+				if v.Options.UnmatchedRoutePolicy == validator.UnmatchedRouteReject && errors.Is(err, routers.ErrMethodNotAllowed) {
+					c.Response().Header().Set("Allow", strings.Join(v.PathMethods(path), ", "))
+				}
+				return httpError(rejection)
+			}
+
+			pathParams := validator.PathParamsFor(route.Path, c.ParamValues())
+			r, err := v.ValidateRequest(req, route, pathParams)
+			if err != nil {
+				return httpError(err)
+			}
+			c.SetRequest(r)
+
+			if !v.Options.ValidateResponses {
+				return next(c)
+			}
+
+			// Handler errors are rendered inside the validated response, as Echo's own
+			// logging middleware does, so error responses are validated too.
+			res := c.Response()
+			w := res.Writer
+			v.ServeResponse(w, r, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				res.Writer = rw
+				c.SetRequest(r)
+				if err := next(c); err != nil {
+					c.Error(err)
+				}
+			}), route, pathParams)
+			res.Writer = w
+			return nil
+		}
+	}
+}
+
+// HTTPErrorHandler returns an echo.HTTPErrorHandler that renders validation errors with the
+// validator's ErrorEncoder (e.g. ProblemDetailsErrorEncoder) and hands every other error to next,
+// typically Echo.DefaultHTTPErrorHandler.
+func HTTPErrorHandler(v *validator.Validator, next echopkg.HTTPErrorHandler) echopkg.HTTPErrorHandler {
+	return func(err error, c echopkg.Context) {
+		var httpErr *validator.HTTPError
+		if !errors.As(err, &httpErr) || c.Response().Committed {
+			next(err, c)
+			return
+		}
+		v.Options.ErrorEncoder(c.Response(), c.Request(), httpErr)
+	}
+}
+
+// httpError converts a validation error into an *echo.HTTPError.
+func httpError(err error) *echopkg.HTTPError {
+	return echopkg.NewHTTPError(validator.StatusCode(err), validator.NewValidationError(err)).SetInternal(err)
+}
@@ -0,0 +1,177 @@
+package echo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	echopkg "github.com/labstack/echo/v4"
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{userId}:
+    get:
+      operationId: getUser
+      parameters:
+        - {name: userId, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name: {type: string}
+`
+
+func newValidator(t *testing.T, opts ...validator.Option) *validator.Validator {
+	t.Helper()
+	v, err := validator.NewFromBytes([]byte(testSpec), opts...)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	return v
+}
+
+func TestMiddleware(t *testing.T) {
+	v := newValidator(t)
+
+	var userID int
+	e := echopkg.New()
+	e.Use(Middleware(v))
+	e.GET("/users/:id", func(c echopkg.Context) error {
+		userID, _ = validator.ParamsFromContext(c.Request().Context()).Int("userId")
+		return c.JSON(http.StatusOK, map[string]string{"name": "amy"})
+	})
+	e.GET("/other", func(c echopkg.Context) error {
+		return c.String(http.StatusOK, "other")
+	})
+
+	t.Run("Valid Request", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(rec, httptest.NewRequest("GET", "/users/42", nil))
+
+		// Assert
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if userID != 42 {
+			t.Errorf("expected userId 42, got %d", userID)
+		}
+	})
+
+	t.Run("Invalid Request Reaches Error Handler", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(rec, httptest.NewRequest("GET", "/users/abc", nil))
+
+		// Assert
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rec.Code)
+		}
+		var body validator.ValidationError
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if len(body.Details) == 0 || body.Details[0].Name != "userId" {
+			t.Errorf("expected a userId field error, got %+v", body.Details)
+		}
+	})
+
+	t.Run("Undocumented Route Passes Through", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(rec, httptest.NewRequest("GET", "/other", nil))
+
+		// Assert
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", rec.Code)
+		}
+	})
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	// Arrange
+	v := newValidator(t, validator.WithErrorEncoder(validator.ProblemDetailsErrorEncoder))
+	e := echopkg.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(v, e.DefaultHTTPErrorHandler)
+	api := e.Group("/api", Middleware(v, validator.WithPathPrefix("/api")))
+	api.GET("/users/:id", func(c echopkg.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"name": "amy"})
+	})
+	e.GET("/boom", func(c echopkg.Context) error {
+		return errors.New("boom")
+	})
+
+	t.Run("Validation Errors Use The Encoder", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(rec, httptest.NewRequest("GET", "/api/users/abc", nil))
+
+		// Assert
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+			t.Errorf("expected problem details, got %q", ct)
+		}
+	})
+
+	t.Run("Other Errors Use Next", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		e.ServeHTTP(rec, httptest.NewRequest("GET", "/boom", nil))
+
+		// Assert
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected 500, got %d", rec.Code)
+		}
+	})
+}
+
+func TestMiddlewareStrictResponses(t *testing.T) {
+	// Arrange
+	v := newValidator(t,
+		validator.WithStrictResponses(http.StatusBadGateway),
+		validator.WithResponseErrorHandler(func(*http.Request, int, error) {}),
+	)
+	e := echopkg.New()
+	e.Use(Middleware(v))
+	e.GET("/users/:id", func(c echopkg.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"nickname": "amy"})
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/users/1", nil))
+
+	// Assert
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", rec.Code)
+	}
+	if strings.HasPrefix(rec.Body.String(), `{"nickname"`) {
+		t.Error("expected the invalid response to be withheld")
+	}
+}
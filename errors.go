@@ -2,14 +2,22 @@ package openapi_validator
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
 )
 
 // ValidationError represents a failed validation attempt.
-// It includes a top-level message and an optional list of detailed error strings.
+// It includes a top-level message, an optional list of detailed error strings
+// and the structured, field-level failures they were derived from.
 type ValidationError struct {
-	Message string   `json:"message"`
-	Errors  []string `json:"errors,omitempty"`
+	Message string       `json:"message"`
+	Errors  []string     `json:"errors,omitempty"`
+	Details []FieldError `json:"details,omitempty"`
 }
 
 // Error implements the error interface for ValidationError.
@@ -17,16 +25,153 @@ func (e *ValidationError) Error() string {
 	return e.Message
 }
 
+// FieldError describes a single validation failure, precise enough for clients to highlight
+// the offending form field.
+type FieldError struct {
+	// In is the location of the failing value: path, query, header, cookie, body or security.
+	In string `json:"in,omitempty"`
+	// Name is the name of the failing parameter or security scheme, if any.
+	Name string `json:"name,omitempty"`
+	// Pointer is the JSON pointer (RFC 6901) to the failing value within the parameter or body.
+	Pointer string `json:"pointer,omitempty"`
+	// Keyword is the JSON schema keyword that failed, such as required, type or maxLength.
+	Keyword string `json:"keyword,omitempty"`
+	// Message is a human-readable description of the failure.
+	Message string `json:"message"`
+}
+
+// FieldErrors unwraps the errors returned by kin-openapi request validation
+// (openapi3filter.RequestError, openapi3filter.SecurityRequirementsError, openapi3.SchemaError
+// and openapi3.MultiError) into a flat list of structured field errors.
+// Any other error is reported as a single entry carrying its message.
+func FieldErrors(err error) []FieldError {
+	if err == nil {
+		return nil
+	}
+
+	var details []FieldError
+	collectFieldErrors(err, FieldError{}, &details)
+	return details
+}
+
+// collectFieldErrors appends the failures found in err to details, inheriting location
+// information from base as it walks down the error tree.
+func collectFieldErrors(err error, base FieldError, details *[]FieldError) {
+	switch e := err.(type) {
+	case *HTTPError:
+		collectFieldErrors(e.Err, base, details)
+	case openapi3.MultiError:
+		for _, inner := range e {
+			collectFieldErrors(inner, base, details)
+		}
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			base.In = e.Parameter.In
+			base.Name = e.Parameter.Name
+		} else if e.RequestBody != nil {
+			base.In = "body"
+		}
+		switch e.Err.(type) {
+		case nil:
+			base.Message = e.Reason
+			*details = append(*details, base)
+		case openapi3.MultiError, *openapi3.SchemaError, *openapi3filter.ParseError:
+			collectFieldErrors(e.Err, base, details)
+		default:
+			base.Message = e.Err.Error()
+			if e.Reason != "" && e.Reason != base.Message {
+				base.Message = e.Reason + ": " + base.Message
+			}
+			*details = append(*details, base)
+		}
+	case *openapi3filter.SecurityRequirementsError:
+		base.In = "security"
+		if len(e.Errors) == 0 {
+			base.Message = e.Error()
+			*details = append(*details, base)
+		}
+		for _, inner := range e.Errors {
+			base.Name = ""
+			base.Message = inner.Error()
+			var schemeErr *securitySchemeError
+			if errors.As(inner, &schemeErr) {
+				base.Name = schemeErr.scheme
+				base.Message = schemeErr.err.Error()
+			}
+			*details = append(*details, base)
+		}
+	case *openapi3.SchemaError:
+		base.Pointer = jsonPointer(e.JSONPointer())
+		base.Keyword = e.SchemaField
+		base.Message = e.Reason
+		*details = append(*details, base)
+	case *openapi3filter.ParseError:
+		var path []string
+		for _, p := range e.Path() {
+			path = append(path, fmt.Sprint(p))
+		}
+		base.Pointer = jsonPointer(path)
+		base.Message = e.Error()
+		*details = append(*details, base)
+	default:
+		if inner := errors.Unwrap(err); inner != nil && isStructured(inner) {
+			collectFieldErrors(inner, base, details)
+			return
+		}
+		base.Message = err.Error()
+		*details = append(*details, base)
+	}
+}
+
+// isStructured reports whether err carries information collectFieldErrors knows how to extract.
+func isStructured(err error) bool {
+	var (
+		requestErr  *openapi3filter.RequestError
+		securityErr *openapi3filter.SecurityRequirementsError
+		schemaErr   *openapi3.SchemaError
+		multiErr    openapi3.MultiError
+	)
+	return errors.As(err, &requestErr) || errors.As(err, &securityErr) ||
+		errors.As(err, &schemaErr) || errors.As(err, &multiErr)
+}
+
+// jsonPointer encodes path segments as an RFC 6901 JSON pointer.
+func jsonPointer(path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	var b strings.Builder
+	for _, segment := range path {
+		b.WriteByte('/')
+		b.WriteString(escaper.Replace(segment))
+	}
+	return b.String()
+}
+
 // DefaultErrorEncoder is a built-in implementation of ErrorEncoder.
-// It sends a JSON response with a 400 Bad Request status code.
+// It sends a JSON response with the status code derived from the error (see StatusCode),
+// which is 400 Bad Request for regular validation failures.
 func DefaultErrorEncoder(w http.ResponseWriter, r *http.Request, err error) {
+	status := StatusCode(err)
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
+	w.WriteHeader(status)
 
-	resp := ValidationError{
-		Message: "Validation Failed",
-		Errors:  []string{err.Error()},
+	json.NewEncoder(w).Encode(NewValidationError(err))
+}
+
+// NewValidationError builds the ValidationError body DefaultErrorEncoder sends for err.
+func NewValidationError(err error) ValidationError {
+	message := "Validation Failed"
+	if status := StatusCode(err); status != http.StatusBadRequest {
+		message = http.StatusText(status)
 	}
 
-	json.NewEncoder(w).Encode(resp)
+	return ValidationError{
+		Message: message,
+		Errors:  []string{err.Error()},
+		Details: FieldErrors(err),
+	}
 }
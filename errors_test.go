@@ -7,6 +7,9 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
 )
 
 func TestValidationError(t *testing.T) {
@@ -56,3 +59,132 @@ func TestDefaultErrorEncoder(t *testing.T) {
 		t.Errorf("expected error details to contain 'test validation error', got %v", resp.Errors)
 	}
 }
+
+func TestFieldErrors(t *testing.T) {
+	t.Run("Plain Error", func(t *testing.T) {
+		// Arrange & Act
+		details := FieldErrors(errors.New("boom"))
+
+		// Assert
+		if len(details) != 1 || details[0].Message != "boom" {
+			t.Errorf("expected a single detail with message boom, got %v", details)
+		}
+	})
+
+	t.Run("Nil Error", func(t *testing.T) {
+		// Arrange & Act
+		details := FieldErrors(nil)
+
+		// Assert
+		if details != nil {
+			t.Errorf("expected no details, got %v", details)
+		}
+	})
+
+	t.Run("Parameter Error", func(t *testing.T) {
+		// Arrange
+		err := &openapi3filter.RequestError{
+			Parameter: &openapi3.Parameter{Name: "limit", In: "query"},
+			Err:       &openapi3.SchemaError{SchemaField: "maximum", Reason: "number must be at most 10"},
+		}
+
+		// Act
+		details := FieldErrors(err)
+
+		// Assert
+		want := FieldError{In: "query", Name: "limit", Keyword: "maximum", Message: "number must be at most 10"}
+		if len(details) != 1 || details[0] != want {
+			t.Errorf("expected %v, got %v", want, details)
+		}
+	})
+
+	t.Run("Security Error", func(t *testing.T) {
+		// Arrange
+		err := &openapi3filter.SecurityRequirementsError{
+			Errors: []error{errors.New("missing api key"), errors.New("missing bearer token")},
+		}
+
+		// Act
+		details := FieldErrors(err)
+
+		// Assert
+		if len(details) != 2 || details[0].In != "security" || details[1].Message != "missing bearer token" {
+			t.Errorf("expected one security detail per alternative, got %v", details)
+		}
+	})
+}
+
+func TestJSONPointer(t *testing.T) {
+	// Arrange & Act
+	got := jsonPointer([]string{"address", "a/b", "c~d"})
+
+	// Assert
+	if got != "/address/a~1b/c~0d" {
+		t.Errorf("expected /address/a~1b/c~0d, got %s", got)
+	}
+}
+
+func TestDefaultErrorEncoder_FieldErrors(t *testing.T) {
+	v, err := NewFromBytes([]byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, maximum: 10}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name: {type: string}
+                address:
+                  type: object
+                  properties:
+                    zip: {type: string, minLength: 5}
+      responses:
+        '200':
+          description: OK
+`))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Arrange
+	req := httptest.NewRequest("POST", "/users?limit=20", strings.NewReader(`{"address":{"zip":"1"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, req)
+
+	// Assert
+	var resp ValidationError
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	want := []FieldError{
+		{In: "query", Name: "limit", Keyword: "maximum", Message: "number must be at most 10"},
+		{In: "body", Pointer: "/address/zip", Keyword: "minLength", Message: "minimum string length is 5"},
+		{In: "body", Pointer: "/name", Keyword: "required", Message: `property "name" is missing`},
+	}
+	if len(resp.Details) != len(want) {
+		t.Fatalf("expected %d details, got %v", len(want), resp.Details)
+	}
+	for i := range want {
+		if resp.Details[i] != want[i] {
+			t.Errorf("expected detail %d to be %v, got %v", i, want[i], resp.Details[i])
+		}
+	}
+}
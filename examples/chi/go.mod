@@ -0,0 +1,29 @@
+module github.com/vihuvac/go-openapi-validator/examples/chi
+
+go 1.25.5
+
+require (
+	github.com/go-chi/chi/v5 v5.3.2
+	github.com/vihuvac/go-openapi-validator v0.0.0-00010101000000-000000000000
+	github.com/vihuvac/go-openapi-validator/chi v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/getkin/kin-openapi v0.133.0 // indirect
+	github.com/go-openapi/jsonpointer v0.21.0 // indirect
+	github.com/go-openapi/swag v0.23.0 // indirect
+	github.com/gorilla/mux v1.8.0 // indirect
+	github.com/josharian/intern v1.0.0 // indirect
+	github.com/mailru/easyjson v0.7.7 // indirect
+	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
+	github.com/oasdiff/yaml v0.0.0-20250309154309-f31be36b4037 // indirect
+	github.com/oasdiff/yaml3 v0.0.0-20250309153720-d2182401db90 // indirect
+	github.com/perimeterx/marshmallow v1.1.5 // indirect
+	github.com/woodsbury/decimal128 v1.3.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/vihuvac/go-openapi-validator => ../../
+	github.com/vihuvac/go-openapi-validator/chi => ../../chi
+)
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	validator "github.com/vihuvac/go-openapi-validator"
+	openapichi "github.com/vihuvac/go-openapi-validator/chi"
+)
+
+type ApiResponse struct {
+	Message string `json:"message"`
+}
+
+func main() {
+	v, err := validator.New(
+		"openapi.yaml",
+		validator.WithValidateRequests(true),
+		validator.WithValidateResponses(true),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+
+	// Validate requests against the operation matching the Chi route pattern.
+	r.Use(openapichi.Middleware(v))
+
+	// Register Swagger UI using SwaggerUIHandler for Chi.
+	r.Handle(v.Options.SwaggerUIPath+"/*", v.SwaggerUIHandler())
+
+	r.Get("/health/liveness", func(w http.ResponseWriter, r *http.Request) {
+		resp := ApiResponse{
+			Message: "Ok",
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	r.Get("/health/readiness", func(w http.ResponseWriter, r *http.Request) {
+		resp := ApiResponse{
+			Message: "Ok",
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	log.Println("Server starting on :8081")
+	log.Println("Swagger UI available at http://localhost:8081/docs")
+	if err := http.ListenAndServe(":8081", r); err != nil {
+		log.Fatal(err)
+	}
+}
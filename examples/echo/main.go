@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	validator "github.com/vihuvac/go-openapi-validator"
+	openapiecho "github.com/vihuvac/go-openapi-validator/echo"
+)
+
+type ApiResponse struct {
+	Message string `json:"message"`
+}
+
+func main() {
+	v, err := validator.New(
+		"openapi.yaml",
+		validator.WithValidateRequests(true),
+		validator.WithValidateResponses(true),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	e := echo.New()
+
+	// Render validation errors with the validator's ErrorEncoder, everything else as usual.
+	e.HTTPErrorHandler = openapiecho.HTTPErrorHandler(v, e.DefaultHTTPErrorHandler)
+
+	// Validate requests inside Echo's routing, matching operations by Echo route.
+	e.Use(openapiecho.Middleware(v))
+
+	// Register Swagger UI using SwaggerUIHandler for Echo.
+	e.GET(v.Options.SwaggerUIPath+"/*", echo.WrapHandler(v.SwaggerUIHandler()))
+
+	e.GET("/health/liveness", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, ApiResponse{
+			Message: "Ok",
+		})
+	})
+
+	e.GET("/health/readiness", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, ApiResponse{
+			Message: "Ok",
+		})
+	})
+
+	log.Println("Server starting on :8081")
+	log.Println("Swagger UI available at http://localhost:8081/docs")
+	if err := http.ListenAndServe(":8081", e); err != nil {
+		log.Fatal(err)
+	}
+}
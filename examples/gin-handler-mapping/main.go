@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	validator "github.com/vihuvac/go-openapi-validator"
+	openapigin "github.com/vihuvac/go-openapi-validator/gin"
 )
 
 func main() {
@@ -31,14 +32,9 @@ func main() {
 		"CheckReadiness": healthController.CheckReadiness,
 	}
 
-	// Automate route registration based on OpenAPI spec
-	for path, pathItem := range v.Swagger.Paths.Map() {
-		for method, operation := range pathItem.Operations() {
-			if handler, ok := handlers[operation.OperationID]; ok {
-				log.Printf("Registering route: %s %s (operationId: %s)", method, path, operation.OperationID)
-				r.Handle(method, path, handler)
-			}
-		}
+	// Register every operation of the spec by operationId, failing on missing or unknown handlers.
+	if err := openapigin.Mount(v, r, handlers); err != nil {
+		log.Fatal(err)
 	}
 
 	// Wrap Gin engine with validator middleware.
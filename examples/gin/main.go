@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	validator "github.com/vihuvac/go-openapi-validator"
+	openapigin "github.com/vihuvac/go-openapi-validator/gin"
 )
 
 type ApiResponse struct {
@@ -24,6 +25,9 @@ func main() {
 
 	r := gin.Default()
 
+	// Validate requests inside Gin's routing, matching operations by Gin route.
+	r.Use(openapigin.Middleware(v))
+
 	// Register Swagger UI using SwaggerUIHandler for Gin-gonic.
 	r.GET(v.Options.SwaggerUIPath+"/*any", gin.WrapH(v.SwaggerUIHandler()))
 
@@ -40,12 +44,9 @@ func main() {
 		})
 	})
 
-	// Wrap Gin engine with validator middleware.
-	handler := v.Middleware(r)
-
 	log.Println("Server starting on :8081")
 	log.Println("Swagger UI available at http://localhost:8081/docs")
-	if err := http.ListenAndServe(":8081", handler); err != nil {
+	if err := http.ListenAndServe(":8081", r); err != nil {
 		log.Fatal(err)
 	}
 }
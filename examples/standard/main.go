@@ -5,7 +5,6 @@ import (
 	"log"
 	"net/http"
 
-	"github.com/getkin/kin-openapi/routers/legacy"
 	validator "github.com/vihuvac/go-openapi-validator"
 )
 
@@ -18,30 +17,19 @@ type HelloResponse struct {
 }
 
 func main() {
-	v, err := validator.New("openapi.yaml")
+	// Match operations with the same method and wildcard patterns as http.ServeMux.
+	v, err := validator.New("openapi.yaml", validator.WithStdlibRouting())
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// For net/http, we need to explicitly set the legacy router.
-	router, err := legacy.NewRouter(v.Swagger)
-	if err != nil {
-		log.Fatal(err)
-	}
-	validator.WithRouter(router)(v.Options)
-
 	mux := http.NewServeMux()
 
 	// Register Swagger UI.
 	v.HandleSwaggerUI(mux)
 
 	// API Handler.
-	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
-
+	mux.HandleFunc("POST /hello", func(w http.ResponseWriter, r *http.Request) {
 		var req HelloRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -0,0 +1,193 @@
+// Package gin adapts the OpenAPI validator to Gin. Unlike wrapping the engine with
+// Validator.Middleware, the handler runs inside Gin's routing: operations are matched by the
+// Gin route (c.FullPath()), failures abort the gin.Context, and the handler can be attached
+// to individual route groups.
+package gin
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/routers"
+	ginpkg "github.com/gin-gonic/gin"
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+const (
+	// ParamsKey is the gin.Context key holding the *validator.Params decoded for the request.
+	ParamsKey = "openapi.params"
+	// OperationKey is the gin.Context key holding the validator.OperationInfo of the matched operation.
+	OperationKey = "openapi.operation"
+)
+
+// Middleware returns a gin.HandlerFunc validating requests, and responses when
+// ValidateResponses is enabled, against the operation matching the Gin route.
+//
+// Failed requests are answered by the validator's ErrorEncoder, like Validator.Middleware does,
+// and the gin.Context is aborted. Validated parameters are stored in the gin.Context under
+// ParamsKey and are also available from the request context. Responses are validated like Validator.Middleware does: violations
+// go to the ResponseErrorHandler, and with StrictResponses invalid responses are replaced by the
+// ErrorEncoder's error response.
+func Middleware(v *validator.Validator, opts ...validator.AdapterOption) ginpkg.HandlerFunc {
+	cfg := validator.NewAdapterOptions(opts...)
+
+	return func(c *ginpkg.Context) {
+		fullPath := c.FullPath()
+		// Requests Gin could not route are answered by Gin itself.
+		if fullPath == "" || strings.HasPrefix(c.Request.URL.Path, v.Options.SwaggerUIPath) {
+			c.Next()
+			return
+		}
+
+		path := cfg.OperationPath(fullPath)
+		route, err := v.OperationRoute(c.Request.Method, path)
+		if err != nil {
+			if rejection := v.UnmatchedRoute(c.Request, err); rejection != nil {
+				if v.Options.UnmatchedRoutePolicy == validator.UnmatchedRouteReject && errors.Is(err, routers.ErrMethodNotAllowed) {
+					c.Header("Allow", strings.Join(v.PathMethods(path), ", "))
+				}
+				abort(c, v, rejection)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		values := make([]string, len(c.Params))
+		for i, param := range c.Params {
+			// Catch-all parameters include the leading slash.
+			values[i] = strings.TrimPrefix(param.Value, "/")
+		}
+		pathParams := validator.PathParamsFor(route.Path, values)
+		r, err := v.ValidateRequest(c.Request, route, pathParams)
+		if err != nil {
+			abort(c, v, err)
+			return
+		}
+		c.Request = r
+
+		c.Set(ParamsKey, validator.ParamsFromContext(r.Context()))
+		if op, ok := validator.OperationFromContext(r.Context()); ok {
+			c.Set(OperationKey, op)
+		}
+
+		if !v.Options.ValidateResponses {
+			c.Next()
+			return
+		}
+
+		// Responses go through the root package's pipeline, so strict mode, streaming media types
+		// and the body size limit apply as they do for Validator.Middleware.
+		w := c.Writer
+		v.ServeResponse(w, c.Request, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			writer := &responseWriter{ResponseWriter: rw, gin: w, status: http.StatusOK, size: noWritten}
+			c.Writer = writer
+			c.Next()
+			// Send a status set without a body, e.g. by c.Status, as Gin does once handlers return.
+			writer.WriteHeaderNow()
+		}), route, pathParams)
+		c.Writer = w
+	}
+}
+
+// Params returns the parameters decoded by the middleware for the request of c.
+func Params(c *ginpkg.Context) *validator.Params {
+	if params, ok := c.Get(ParamsKey); ok {
+		return params.(*validator.Params)
+	}
+	return validator.ParamsFromContext(c.Request.Context())
+}
+
+// abort stops the handler chain, answering with the error response the ErrorEncoder of v
+// builds for err.
+func abort(c *ginpkg.Context, v *validator.Validator, err error) {
+	c.Abort()
+	v.Options.ErrorEncoder(c.Writer, c.Request, err)
+}
+
+// noWritten is the size of a response whose header has not been written yet, as in Gin.
+const noWritten = -1
+
+// responseWriter adapts the http.ResponseWriter of ServeResponse to the gin.ResponseWriter Gin
+// handlers write to. Like Gin's own writer, it defers the header until the body is written.
+type responseWriter struct {
+	http.ResponseWriter
+	// gin is the writer of the gin.Context, used for the methods net/http has no equivalent of.
+	gin    ginpkg.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if code > 0 && !w.Written() {
+		w.status = code
+	}
+}
+
+func (w *responseWriter) WriteHeaderNow() {
+	if !w.Written() {
+		w.size = 0
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.WriteHeaderNow()
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+func (w *responseWriter) WriteString(s string) (int, error) {
+	w.WriteHeaderNow()
+	n, err := io.WriteString(w.ResponseWriter, s)
+	w.size += n
+	return n, err
+}
+
+func (w *responseWriter) Status() int {
+	return w.status
+}
+
+func (w *responseWriter) Size() int {
+	return w.size
+}
+
+func (w *responseWriter) Written() bool {
+	return w.size != noWritten
+}
+
+func (w *responseWriter) Flush() {
+	w.WriteHeaderNow()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("the response writer does not support hijacking")
+	}
+	if w.size < 0 {
+		w.size = 0
+	}
+	return h.Hijack()
+}
+
+func (w *responseWriter) CloseNotify() <-chan bool {
+	return w.gin.CloseNotify()
+}
+
+func (w *responseWriter) Pusher() http.Pusher {
+	return w.gin.Pusher()
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
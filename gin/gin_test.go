@@ -0,0 +1,355 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ginpkg "github.com/gin-gonic/gin"
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/{userId}:
+    get:
+      operationId: getUser
+      parameters:
+        - {name: userId, in: path, required: true, schema: {type: integer}}
+        - {name: verbose, in: query, schema: {type: boolean}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name: {type: string}
+`
+
+func init() {
+	ginpkg.SetMode(ginpkg.TestMode)
+}
+
+func newValidator(t *testing.T, spec string, opts ...validator.Option) *validator.Validator {
+	t.Helper()
+	v, err := validator.NewFromBytes([]byte(spec), opts...)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	return v
+}
+
+func TestMiddleware(t *testing.T) {
+	v := newValidator(t, testSpec)
+
+	var (
+		params *validator.Params
+		op     any
+	)
+	engine := ginpkg.New()
+	engine.Use(Middleware(v))
+	engine.GET("/users/:id", func(c *ginpkg.Context) {
+		params = Params(c)
+		op, _ = c.Get(OperationKey)
+		c.JSON(http.StatusOK, ginpkg.H{"name": "amy"})
+	})
+	engine.GET("/other", func(c *ginpkg.Context) {
+		c.String(http.StatusOK, "other")
+	})
+
+	t.Run("Valid Request", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/users/42?verbose=true", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		engine.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if id, ok := params.Int("userId"); !ok || id != 42 {
+			t.Errorf("expected userId 42, got %v (%v)", id, ok)
+		}
+		if verbose, ok := params.Bool("verbose"); !ok || !verbose {
+			t.Errorf("expected verbose true, got %v (%v)", verbose, ok)
+		}
+		if info, ok := op.(validator.OperationInfo); !ok || info.ID != "getUser" {
+			t.Errorf("expected operation getUser, got %v", op)
+		}
+	})
+
+	t.Run("Invalid Request Aborts", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/users/abc", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		engine.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rec.Code)
+		}
+		var body validator.ValidationError
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if len(body.Details) == 0 || body.Details[0].Name != "userId" {
+			t.Errorf("expected a userId field error, got %+v", body.Details)
+		}
+	})
+
+	t.Run("Undocumented Route Passes Through", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/other", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		engine.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusOK || rec.Body.String() != "other" {
+			t.Errorf("expected pass through, got %d %q", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+func TestMiddlewareErrorEncoder(t *testing.T) {
+	// Arrange
+	v := newValidator(t, testSpec, validator.WithErrorEncoder(validator.ProblemDetailsErrorEncoder))
+	engine := ginpkg.New()
+	engine.Use(Middleware(v))
+	engine.GET("/users/:id", func(c *ginpkg.Context) {
+		t.Error("expected the handler not to be called")
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	engine.ServeHTTP(rec, httptest.NewRequest("GET", "/users/abc", nil))
+
+	// Assert
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("expected problem details, got %q", ct)
+	}
+}
+
+func TestMiddlewareRouteGroup(t *testing.T) {
+	// Arrange
+	v := newValidator(t, testSpec)
+	engine := ginpkg.New()
+	api := engine.Group("/api/v1", Middleware(v, validator.WithPathPrefix("/api/v1")))
+	api.GET("/users/:id", func(c *ginpkg.Context) {
+		c.JSON(http.StatusOK, ginpkg.H{"name": "amy"})
+	})
+	engine.GET("/users/:id", func(c *ginpkg.Context) {
+		c.String(http.StatusOK, "unvalidated")
+	})
+
+	t.Run("Group Is Validated", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		engine.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/users/abc", nil))
+
+		// Assert
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Routes Outside The Group Are Not", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		engine.ServeHTTP(rec, httptest.NewRequest("GET", "/users/abc", nil))
+
+		// Assert
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", rec.Code)
+		}
+	})
+}
+
+func TestMiddlewareServerBasePath(t *testing.T) {
+	// Arrange
+	v := newValidator(t, strings.Replace(testSpec, "paths:", "servers:\n  - url: https://example.com/api\npaths:", 1))
+	engine := ginpkg.New()
+	engine.Use(Middleware(v))
+	engine.GET("/api/users/:id", func(c *ginpkg.Context) {
+		c.JSON(http.StatusOK, ginpkg.H{"name": "amy"})
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	engine.ServeHTTP(rec, httptest.NewRequest("GET", "/api/users/abc", nil))
+
+	// Assert
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestMiddlewareUnmatchedRoutePolicy(t *testing.T) {
+	// Arrange
+	v := newValidator(t, testSpec, validator.WithUnmatchedRoutePolicy(validator.UnmatchedRouteReject))
+	engine := ginpkg.New()
+	engine.Use(Middleware(v))
+	engine.DELETE("/users/:id", func(c *ginpkg.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	engine.ServeHTTP(rec, httptest.NewRequest("DELETE", "/users/1", nil))
+
+	// Assert
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "GET" {
+		t.Errorf("expected Allow GET, got %q", allow)
+	}
+}
+
+func TestMiddlewareResponseValidation(t *testing.T) {
+	// Arrange
+	reported := make(chan error, 1)
+	v := newValidator(t, testSpec,
+		validator.WithValidateResponses(true),
+		validator.WithResponseErrorHandler(func(r *http.Request, status int, err error) {
+			reported <- err
+		}),
+	)
+	engine := ginpkg.New()
+	engine.Use(Middleware(v))
+	engine.GET("/users/:id", func(c *ginpkg.Context) {
+		c.JSON(http.StatusOK, ginpkg.H{"nickname": "amy"})
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	engine.ServeHTTP(rec, httptest.NewRequest("GET", "/users/1", nil))
+
+	// Assert
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected the response to be sent, got %d", rec.Code)
+	}
+	select {
+	case err := <-reported:
+		if !strings.Contains(err.Error(), "name") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	default:
+		t.Error("expected the invalid response to be reported")
+	}
+}
+
+func TestMiddlewareStatusWithoutBody(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info: {title: Test API, version: 1.0.0}
+paths:
+  /users/{userId}:
+    delete:
+      parameters:
+        - {name: userId, in: path, required: true, schema: {type: integer}}
+      responses:
+        '204': {description: No Content}
+`
+	tests := []struct {
+		name string
+		opts []validator.Option
+	}{
+		{name: "Report", opts: []validator.Option{validator.WithValidateResponses(true)}},
+		{name: "Strict", opts: []validator.Option{validator.WithStrictResponses(http.StatusBadGateway)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var reported []error
+			v := newValidator(t, spec, append(tt.opts, validator.WithResponseErrorHandler(func(r *http.Request, status int, err error) {
+				reported = append(reported, err)
+			}))...)
+			engine := ginpkg.New()
+			engine.Use(Middleware(v))
+			engine.DELETE("/users/:id", func(c *ginpkg.Context) {
+				c.Status(http.StatusNoContent)
+			})
+			rec := httptest.NewRecorder()
+
+			// Act
+			engine.ServeHTTP(rec, httptest.NewRequest("DELETE", "/users/1", nil))
+
+			// Assert
+			if rec.Code != http.StatusNoContent || rec.Body.Len() != 0 {
+				t.Errorf("expected 204 without a body, got %d %s", rec.Code, rec.Body.String())
+			}
+			if len(reported) != 0 {
+				t.Errorf("expected the 204 to be validated, got %v", reported)
+			}
+		})
+	}
+}
+
+func TestMiddlewareStrictResponses(t *testing.T) {
+	v := newValidator(t, testSpec,
+		validator.WithStrictResponses(http.StatusBadGateway),
+		validator.WithResponseErrorHandler(nil),
+	)
+	engine := ginpkg.New()
+	engine.Use(Middleware(v))
+	engine.GET("/users/:id", func(c *ginpkg.Context) {
+		if c.Param("id") == "1" {
+			c.JSON(http.StatusOK, ginpkg.H{"name": "amy"})
+			return
+		}
+		c.JSON(http.StatusOK, ginpkg.H{"nickname": "amy"})
+	})
+
+	t.Run("Valid Response Is Sent", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		engine.ServeHTTP(rec, httptest.NewRequest("GET", "/users/1", nil))
+
+		// Assert
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"amy"`) {
+			t.Errorf("expected the response to be sent, got %d %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Invalid Response Is Replaced", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		engine.ServeHTTP(rec, httptest.NewRequest("GET", "/users/2", nil))
+
+		// Assert
+		if rec.Code != http.StatusBadGateway {
+			t.Fatalf("expected 502, got %d", rec.Code)
+		}
+		var body validator.ValidationError
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Message != "Bad Gateway" {
+			t.Errorf("expected the ErrorEncoder's response instead of the invalid body, got %s", rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package gin
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	ginpkg "github.com/gin-gonic/gin"
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+// Registrar returns a validator.RouteRegistrar registering operations on a Gin engine or route
+// group with Gin path syntax (/users/:id). Handlers created with Handler run as native
+// gin.HandlerFuncs; other http.Handlers are wrapped with gin.WrapH.
+func Registrar(routes ginpkg.IRoutes) validator.RouteRegistrar {
+	return validator.RouteRegistrarFunc(func(method, path string, h http.Handler) (err error) {
+		ginPath, err := ginRoutePath(path)
+		if err != nil {
+			return err
+		}
+
+		fn, ok := h.(handler)
+		if !ok {
+			fn = handler(ginpkg.WrapH(h))
+		}
+
+		// Gin panics on conflicting routes.
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+		routes.Handle(method, ginPath, ginpkg.HandlerFunc(fn))
+		return nil
+	})
+}
+
+// Mount registers a gin.HandlerFunc for every operation of the spec on routes, keyed by
+// operationId, as Validator.Mount does for net/http handlers.
+func Mount(v *validator.Validator, routes ginpkg.IRoutes, handlers map[string]ginpkg.HandlerFunc, opts ...validator.MountOption) error {
+	httpHandlers := make(map[string]http.Handler, len(handlers))
+	for key, fn := range handlers {
+		httpHandlers[key] = Handler(fn)
+	}
+	return v.Mount(Registrar(routes), httpHandlers, opts...)
+}
+
+// Handler wraps a gin.HandlerFunc as an http.Handler for the handler map of Validator.Mount.
+// Registrar registers it as the original gin.HandlerFunc.
+func Handler(fn ginpkg.HandlerFunc) http.Handler {
+	return handler(fn)
+}
+
+// handler is a gin.HandlerFunc carried through Validator.Mount.
+type handler ginpkg.HandlerFunc
+
+// ServeHTTP reports a misconfiguration: a gin.HandlerFunc needs a gin.Context to run.
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "gin handler served outside of Gin", http.StatusInternalServerError)
+}
+
+// ginRoutePath translates an OpenAPI path template such as /users/{id} to the Gin route path /users/:id.
+func ginRoutePath(path string) (string, error) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !strings.Contains(segment, "{") {
+			continue
+		}
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") || strings.Count(segment, "{") != 1 {
+			return "", fmt.Errorf("path %q: Gin routes only support parameters spanning a whole segment", path)
+		}
+		segments[i] = ":" + segment[1:len(segment)-1]
+	}
+	return strings.Join(segments, "/"), nil
+}
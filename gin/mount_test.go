@@ -0,0 +1,68 @@
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ginpkg "github.com/gin-gonic/gin"
+)
+
+func TestMount(t *testing.T) {
+	v := newValidator(t, testSpec)
+
+	t.Run("Registers Gin Handlers", func(t *testing.T) {
+		// Arrange
+		engine := ginpkg.New()
+		api := engine.Group("/api")
+
+		// Act
+		err := Mount(v, api, map[string]ginpkg.HandlerFunc{
+			"getUser": func(c *ginpkg.Context) {
+				c.String(http.StatusOK, "user "+c.Param("userId"))
+			},
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest("GET", "/api/users/7", nil))
+		if rec.Body.String() != "user 7" {
+			t.Errorf("expected user 7, got %q", rec.Body.String())
+		}
+	})
+
+	t.Run("Wraps net/http Handlers", func(t *testing.T) {
+		// Arrange
+		engine := ginpkg.New()
+
+		// Act
+		err := v.Mount(Registrar(engine), map[string]http.Handler{
+			"getUser": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("wrapped"))
+			}),
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest("GET", "/users/7", nil))
+		if rec.Body.String() != "wrapped" {
+			t.Errorf("expected wrapped, got %q", rec.Body.String())
+		}
+	})
+
+	t.Run("Missing Handler", func(t *testing.T) {
+		// Act
+		err := Mount(v, ginpkg.New(), map[string]ginpkg.HandlerFunc{})
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Package har validates recorded traffic against an OpenAPI spec offline. HTTP Archive (HAR)
+// files, as exported by browsers and most proxies, are replayed through the validator's request
+// and response validation without a live server, and the outcome is summarized per operation.
+package har
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Log is the log of an HTTP Archive. Only the fields needed for validation are decoded.
+type Log struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is a recorded request and the response received for it.
+type Entry struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method   string    `json:"method"`
+	URL      string    `json:"url"`
+	Headers  []Header  `json:"headers"`
+	PostData *PostData `json:"postData,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Status  int      `json:"status"`
+	Headers []Header `json:"headers"`
+	Content Content  `json:"content"`
+}
+
+// Header is a recorded header field.
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PostData is a recorded request body. Form submissions may be recorded as Params only.
+type PostData struct {
+	MimeType string  `json:"mimeType"`
+	Text     string  `json:"text"`
+	Params   []Param `json:"params,omitempty"`
+}
+
+// Param is a recorded form field.
+type Param struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Content is a recorded response body. Text is base64-encoded when Encoding is "base64".
+type Content struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Decode reads an HTTP Archive from r.
+func Decode(r io.Reader) (*Log, error) {
+	var archive struct {
+		Log *Log `json:"log"`
+	}
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("failed to decode HAR: %w", err)
+	}
+	if archive.Log == nil {
+		return nil, fmt.Errorf("failed to decode HAR: missing log")
+	}
+	return archive.Log, nil
+}
+
+// HTTPRequest rebuilds the recorded request as an *http.Request, as a server would receive it.
+func (r *Request) HTTPRequest() (*http.Request, error) {
+	var body io.Reader
+	if pd := r.PostData; pd != nil {
+		switch {
+		case pd.Text != "":
+			body = strings.NewReader(pd.Text)
+		case len(pd.Params) > 0:
+			form := url.Values{}
+			for _, p := range pd.Params {
+				form.Add(p.Name, p.Value)
+			}
+			body = strings.NewReader(form.Encode())
+		}
+	}
+
+	req, err := http.NewRequest(r.Method, r.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range r.Headers {
+		// HTTP/2 pseudo-headers are already part of the method and URL.
+		if strings.HasPrefix(h.Name, ":") {
+			continue
+		}
+		if strings.EqualFold(h.Name, "Host") {
+			req.Host = h.Value
+			continue
+		}
+		req.Header.Add(h.Name, h.Value)
+	}
+	if r.PostData != nil && r.PostData.MimeType != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", r.PostData.MimeType)
+	}
+	return req, nil
+}
+
+// Header returns the recorded response headers as an http.Header.
+func (r *Response) Header() http.Header {
+	header := make(http.Header, len(r.Headers))
+	for _, h := range r.Headers {
+		if strings.HasPrefix(h.Name, ":") {
+			continue
+		}
+		header.Add(h.Name, h.Value)
+	}
+	if r.Content.MimeType != "" && header.Get("Content-Type") == "" {
+		header.Set("Content-Type", r.Content.MimeType)
+	}
+	return header
+}
+
+// Body returns the recorded response body. It reports false when the body was not recorded,
+// which HAR exporters do for binary or oversized content.
+func (r *Response) Body() ([]byte, bool, error) {
+	if r.Content.Text == "" {
+		return nil, r.Content.Size <= 0, nil
+	}
+	if r.Content.Encoding == "base64" {
+		data, err := base64.StdEncoding.DecodeString(r.Content.Text)
+		return data, err == nil, err
+	}
+	return []byte(r.Content.Text), true, nil
+}
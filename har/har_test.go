@@ -0,0 +1,152 @@
+package har
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	t.Run("Valid Archive", func(t *testing.T) {
+		// Act
+		log, err := Decode(strings.NewReader(`{"log":{"version":"1.2","entries":[{"request":{"method":"GET","url":"http://example.com/"},"response":{"status":200}}]}}`))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(log.Entries) != 1 || log.Entries[0].Response.Status != 200 {
+			t.Errorf("unexpected log: %+v", log)
+		}
+	})
+
+	t.Run("Missing Log", func(t *testing.T) {
+		// Act
+		_, err := Decode(strings.NewReader(`{}`))
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error for a missing log")
+		}
+	})
+
+	t.Run("Malformed JSON", func(t *testing.T) {
+		// Act
+		_, err := Decode(strings.NewReader(`{"log":`))
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error for malformed JSON")
+		}
+	})
+}
+
+func TestRequest_HTTPRequest(t *testing.T) {
+	t.Run("Body And Headers", func(t *testing.T) {
+		// Arrange
+		r := Request{
+			Method: "POST",
+			URL:    "https://api.example.com/v1/pets?limit=2",
+			Headers: []Header{
+				{Name: ":authority", Value: "api.example.com"},
+				{Name: "Host", Value: "api.example.com"},
+				{Name: "X-Request-Id", Value: "42"},
+			},
+			PostData: &PostData{MimeType: "application/json", Text: `{"name":"Rex"}`},
+		}
+
+		// Act
+		req, err := r.HTTPRequest()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"name":"Rex"}` || req.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected body %q or content type %q", body, req.Header.Get("Content-Type"))
+		}
+		if req.Host != "api.example.com" || req.Header.Get("X-Request-Id") != "42" || len(req.Header) != 2 {
+			t.Errorf("unexpected host %q or headers %v", req.Host, req.Header)
+		}
+	})
+
+	t.Run("Form Params", func(t *testing.T) {
+		// Arrange
+		r := Request{
+			Method:   "POST",
+			URL:      "http://example.com/login",
+			PostData: &PostData{MimeType: "application/x-www-form-urlencoded", Params: []Param{{Name: "user", Value: "a b"}}},
+		}
+
+		// Act
+		req, err := r.HTTPRequest()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != "user=a+b" {
+			t.Errorf("expected the encoded form, got %q", body)
+		}
+	})
+
+	t.Run("Malformed URL", func(t *testing.T) {
+		// Act
+		_, err := (&Request{Method: "GET", URL: "http://[::1"}).HTTPRequest()
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error for a malformed URL")
+		}
+	})
+}
+
+func TestResponse_Body(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  Content
+		body     string
+		recorded bool
+	}{
+		{"Text", Content{Size: 2, Text: "{}"}, "{}", true},
+		{"Base64", Content{Size: 2, Text: "e30=", Encoding: "base64"}, "{}", true},
+		{"Empty", Content{}, "", true},
+		{"Not Recorded", Content{Size: 2048}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			r := Response{Content: tt.content}
+
+			// Act
+			body, recorded, err := r.Body()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(body) != tt.body || recorded != tt.recorded {
+				t.Errorf("expected %q (recorded %v), got %q (recorded %v)", tt.body, tt.recorded, body, recorded)
+			}
+		})
+	}
+}
+
+func TestResponse_Header(t *testing.T) {
+	// Arrange
+	r := Response{
+		Headers: []Header{{Name: ":status", Value: "200"}, {Name: "X-Rate-Limit", Value: "10"}},
+		Content: Content{MimeType: "application/json"},
+	}
+
+	// Act
+	header := r.Header()
+
+	// Assert
+	if header.Get("Content-Type") != "application/json" || header.Get("X-Rate-Limit") != "10" || len(header) != 2 {
+		t.Errorf("unexpected headers: %v", header)
+	}
+}
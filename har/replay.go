@@ -0,0 +1,202 @@
+package har
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+// Phase tells whether a violation was found in the request or in the response.
+type Phase string
+
+const (
+	// PhaseRequest marks violations of the recorded request.
+	PhaseRequest Phase = "request"
+	// PhaseResponse marks violations of the recorded response.
+	PhaseResponse Phase = "response"
+)
+
+// Report summarizes how conformant a log is to the spec.
+type Report struct {
+	// Entries is the number of entries replayed.
+	Entries int `json:"entries"`
+	// Operations lists the operations that received traffic, sorted by path and method.
+	Operations []*OperationReport `json:"operations"`
+	// Unmatched lists the requests that match no operation, grouped by method and path.
+	Unmatched []*UnmatchedRoute `json:"unmatched"`
+	// Invalid lists the entries that could not be replayed, e.g. because of a malformed URL.
+	Invalid []EntryError `json:"invalid,omitempty"`
+}
+
+// OperationReport is the outcome of the entries matching one operation.
+type OperationReport struct {
+	OperationID string `json:"operationId,omitempty"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	// Entries is the number of entries matching the operation, and Passed how many of them
+	// have neither violations nor an undeclared status.
+	Entries int `json:"entries"`
+	Passed  int `json:"passed"`
+	// Violations lists the request and response validation failures.
+	Violations []Violation `json:"violations,omitempty"`
+	// UndeclaredStatuses counts the responses by status code that the operation does not declare.
+	UndeclaredStatuses map[int]int `json:"undeclaredStatuses,omitempty"`
+	// SkippedResponses counts the responses whose body was not recorded and so was not validated.
+	SkippedResponses int `json:"skippedResponses,omitempty"`
+}
+
+// Violation is a validation failure of a single entry.
+type Violation struct {
+	// Entry is the index of the entry in the log.
+	Entry  int                    `json:"entry"`
+	Phase  Phase                  `json:"phase"`
+	URL    string                 `json:"url"`
+	Status int                    `json:"status,omitempty"`
+	Errors []validator.FieldError `json:"errors"`
+}
+
+// UnmatchedRoute counts the requests for a method and path that match no operation.
+type UnmatchedRoute struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Reason is "path not found" or "method not allowed".
+	Reason  string `json:"reason"`
+	Entries int    `json:"entries"`
+}
+
+// EntryError is an entry that could not be replayed.
+type EntryError struct {
+	Entry   int    `json:"entry"`
+	Message string `json:"message"`
+}
+
+// OK reports whether every entry matched an operation and passed validation with a declared status.
+func (r *Report) OK() bool {
+	if len(r.Unmatched) > 0 || len(r.Invalid) > 0 {
+		return false
+	}
+	for _, op := range r.Operations {
+		if len(op.Violations) > 0 || len(op.UndeclaredStatuses) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Replay validates every entry of log against the spec of v, with the request and response
+// validation Middleware applies, and returns the per-operation report. Requests are matched with
+// the validator's router, so their URLs must match the spec's servers when those declare hosts.
+// Security requirements are not checked, since recorded credentials cannot be verified offline.
+func Replay(v *validator.Validator, log *Log) *Report {
+	report := &Report{Entries: len(log.Entries), Operations: []*OperationReport{}, Unmatched: []*UnmatchedRoute{}}
+	operations := make(map[string]*OperationReport)
+	unmatched := make(map[string]*UnmatchedRoute)
+
+	for i := range log.Entries {
+		entry := &log.Entries[i]
+		req, err := entry.Request.HTTPRequest()
+		if err != nil {
+			report.Invalid = append(report.Invalid, EntryError{Entry: i, Message: err.Error()})
+			continue
+		}
+
+		route, pathParams, err := v.FindRoute(req)
+		if err != nil {
+			reason := "path not found"
+			if errors.Is(err, routers.ErrMethodNotAllowed) {
+				reason = "method not allowed"
+			}
+			key := req.Method + " " + req.URL.Path
+			if unmatched[key] == nil {
+				unmatched[key] = &UnmatchedRoute{Method: req.Method, Path: req.URL.Path, Reason: reason}
+				report.Unmatched = append(report.Unmatched, unmatched[key])
+			}
+			unmatched[key].Entries++
+			continue
+		}
+
+		key := route.Method + " " + route.Path
+		op := operations[key]
+		if op == nil {
+			op = &OperationReport{OperationID: route.Operation.OperationID, Method: route.Method, Path: route.Path}
+			operations[key] = op
+			report.Operations = append(report.Operations, op)
+		}
+		op.Entries++
+
+		passed := true
+		violation := func(phase Phase, status int, err error) {
+			op.Violations = append(op.Violations, Violation{
+				Entry:  i,
+				Phase:  phase,
+				URL:    entry.Request.URL,
+				Status: status,
+				Errors: validator.FieldErrors(err),
+			})
+			passed = false
+		}
+
+		if err := validateRequest(req, route, pathParams); err != nil {
+			violation(PhaseRequest, 0, err)
+		}
+
+		status := entry.Response.Status
+		if route.Operation.Responses == nil || (route.Operation.Responses.Status(status) == nil && route.Operation.Responses.Default() == nil) {
+			if op.UndeclaredStatuses == nil {
+				op.UndeclaredStatuses = make(map[int]int)
+			}
+			op.UndeclaredStatuses[status]++
+			continue
+		}
+
+		body, recorded, err := entry.Response.Body()
+		switch {
+		case err != nil:
+			violation(PhaseResponse, status, err)
+		case !recorded:
+			op.SkippedResponses++
+		default:
+			if err := v.ValidateResponse(req, route, pathParams, status, entry.Response.Header(), body); err != nil {
+				violation(PhaseResponse, status, err)
+			}
+		}
+		if passed {
+			op.Passed++
+		}
+	}
+
+	sort.SliceStable(report.Operations, func(i, j int) bool {
+		a, b := report.Operations[i], report.Operations[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+	sort.SliceStable(report.Unmatched, func(i, j int) bool {
+		a, b := report.Unmatched[i], report.Unmatched[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+	return report
+}
+
+// validateRequest checks a recorded request against the spec, leaving out its security
+// requirements.
+func validateRequest(req *http.Request, route *routers.Route, pathParams map[string]string) error {
+	return openapi3filter.ValidateRequest(req.Context(), &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			MultiError:          true,
+			SkipSettingDefaults: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+	})
+}
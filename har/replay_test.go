@@ -0,0 +1,149 @@
+package har
+
+import (
+	"testing"
+
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+const testReplaySpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id: {type: integer}
+        '404':
+          description: Not Found
+`
+
+// entry returns a GET entry for url answered with status and body.
+func entry(url string, status int, body string) Entry {
+	return Entry{
+		Request:  Request{Method: "GET", URL: url},
+		Response: Response{Status: status, Content: Content{Size: int64(len(body)), MimeType: "application/json", Text: body}},
+	}
+}
+
+func TestReplay(t *testing.T) {
+	// Arrange
+	v, err := validator.NewFromBytes([]byte(testReplaySpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	log := &Log{Entries: []Entry{
+		entry("http://localhost/pets/1", 200, `{"id":1}`),
+		entry("http://localhost/pets/2", 404, ``),
+		entry("http://localhost/pets/abc", 404, ``),
+		entry("http://localhost/pets/3", 200, `{"id":"three"}`),
+		entry("http://localhost/pets/4", 500, `{}`),
+		{Request: Request{Method: "GET", URL: "http://localhost/pets/5"}, Response: Response{Status: 200, Content: Content{Size: 4096}}},
+		entry("http://localhost/owners/1", 200, `{}`),
+		entry("http://localhost/owners/1", 200, `{}`),
+		{Request: Request{Method: "DELETE", URL: "http://localhost/pets/1"}, Response: Response{Status: 204}},
+		{Request: Request{Method: "GET", URL: "http://[::1"}},
+	}}
+
+	// Act
+	report := Replay(v, log)
+
+	// Assert
+	if report.OK() {
+		t.Error("expected the report not to be OK")
+	}
+	if report.Entries != 10 || len(report.Operations) != 1 {
+		t.Fatalf("expected 10 entries for one operation, got %+v", report)
+	}
+
+	op := report.Operations[0]
+	if op.OperationID != "getPet" || op.Method != "GET" || op.Path != "/pets/{id}" {
+		t.Errorf("unexpected operation: %+v", op)
+	}
+	if op.Entries != 6 || op.Passed != 3 || op.SkippedResponses != 1 {
+		t.Errorf("expected 6 entries, 3 passed and 1 skipped, got %+v", op)
+	}
+	if len(op.Violations) != 2 || op.Violations[0].Phase != PhaseRequest || op.Violations[1].Phase != PhaseResponse || op.Violations[1].Status != 200 {
+		t.Errorf("expected a request and a response violation, got %+v", op.Violations)
+	}
+	if op.UndeclaredStatuses[500] != 1 {
+		t.Errorf("expected status 500 to be undeclared, got %v", op.UndeclaredStatuses)
+	}
+
+	if len(report.Unmatched) != 2 {
+		t.Fatalf("expected two unmatched routes, got %+v", report.Unmatched)
+	}
+	if u := report.Unmatched[0]; u.Path != "/owners/1" || u.Entries != 2 || u.Reason != "path not found" {
+		t.Errorf("unexpected unmatched route: %+v", u)
+	}
+	if u := report.Unmatched[1]; u.Method != "DELETE" || u.Reason != "method not allowed" {
+		t.Errorf("unexpected unmatched route: %+v", u)
+	}
+	if len(report.Invalid) != 1 || report.Invalid[0].Entry != 9 {
+		t.Errorf("expected the last entry to be invalid, got %+v", report.Invalid)
+	}
+}
+
+func TestReplayConformantLog(t *testing.T) {
+	// Arrange
+	v, err := validator.NewFromBytes([]byte(testReplaySpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	// Act
+	report := Replay(v, &Log{Entries: []Entry{entry("http://localhost/pets/1", 200, `{"id":1}`)}})
+
+	// Assert
+	if !report.OK() || report.Operations[0].Passed != 1 {
+		t.Errorf("expected a conformant report, got %+v", report.Operations[0])
+	}
+}
+
+func TestReplaySecuredOperation(t *testing.T) {
+	// Arrange
+	v, err := validator.NewFromBytes([]byte(`
+openapi: 3.0.0
+info: {title: Test API, version: 1.0.0}
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {type: array, items: {type: string}}
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, in: header, name: X-API-Key}
+`))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	e := entry("http://localhost/pets", 200, `[]`)
+	e.Request.Headers = []Header{{Name: "X-API-Key", Value: "secret"}}
+
+	// Act
+	report := Replay(v, &Log{Entries: []Entry{e}})
+
+	// Assert
+	if !report.OK() || report.Operations[0].Passed != 1 {
+		t.Errorf("expected the secured request to pass, got %+v", report.Operations[0])
+	}
+}
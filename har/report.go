@@ -0,0 +1,88 @@
+package har
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+// WriteText writes the report as a human-readable summary, one block per operation.
+func WriteText(w io.Writer, report *Report) error {
+	var b strings.Builder
+	violations, undeclared := 0, 0
+	for _, op := range report.Operations {
+		name := op.Method + " " + op.Path
+		if op.OperationID != "" {
+			name += " (" + op.OperationID + ")"
+		}
+		fmt.Fprintf(&b, "%s: %d entries, %d passed\n", name, op.Entries, op.Passed)
+		for _, v := range op.Violations {
+			phase := string(v.Phase)
+			if v.Status != 0 {
+				phase = fmt.Sprintf("%s %d", v.Phase, v.Status)
+			}
+			for _, e := range v.Errors {
+				fmt.Fprintf(&b, "  entry %d %s: %s: %s\n", v.Entry, v.URL, phase, fieldErrorText(e))
+			}
+		}
+		for _, status := range sortedStatuses(op.UndeclaredStatuses) {
+			fmt.Fprintf(&b, "  undeclared status %d: %d entries\n", status, op.UndeclaredStatuses[status])
+			undeclared += op.UndeclaredStatuses[status]
+		}
+		if op.SkippedResponses > 0 {
+			fmt.Fprintf(&b, "  %d responses without a recorded body were not validated\n", op.SkippedResponses)
+		}
+		violations += len(op.Violations)
+	}
+
+	if len(report.Unmatched) > 0 {
+		fmt.Fprintln(&b, "Unmatched:")
+		for _, u := range report.Unmatched {
+			fmt.Fprintf(&b, "  %s %s: %d entries (%s)\n", u.Method, u.Path, u.Entries, u.Reason)
+		}
+	}
+	if len(report.Invalid) > 0 {
+		fmt.Fprintln(&b, "Invalid entries:")
+		for _, e := range report.Invalid {
+			fmt.Fprintf(&b, "  entry %d: %s\n", e.Entry, e.Message)
+		}
+	}
+
+	fmt.Fprintf(&b, "%d entries, %d operations, %d violations, %d undeclared statuses, %d unmatched routes\n",
+		report.Entries, len(report.Operations), violations, undeclared, len(report.Unmatched))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the report as JSON.
+func WriteJSON(w io.Writer, report *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// fieldErrorText formats a validation failure with its location.
+func fieldErrorText(e validator.FieldError) string {
+	location := strings.TrimSpace(strings.Join([]string{e.In, e.Name}, " "))
+	if e.Pointer != "" {
+		location = strings.TrimSpace(location + " " + e.Pointer)
+	}
+	if location == "" {
+		return e.Message
+	}
+	return location + ": " + e.Message
+}
+
+// sortedStatuses returns the status codes of counts in ascending order.
+func sortedStatuses(counts map[int]int) []int {
+	statuses := make([]int, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	return statuses
+}
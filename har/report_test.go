@@ -0,0 +1,73 @@
+package har
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validator "github.com/vihuvac/go-openapi-validator"
+)
+
+var testReport = &Report{
+	Entries: 4,
+	Operations: []*OperationReport{{
+		OperationID: "getPet",
+		Method:      "GET",
+		Path:        "/pets/{id}",
+		Entries:     3,
+		Passed:      1,
+		Violations: []Violation{{
+			Entry:  1,
+			Phase:  PhaseRequest,
+			URL:    "http://localhost/pets/abc",
+			Errors: []validator.FieldError{{In: "path", Name: "id", Message: "value must be an integer"}},
+		}},
+		UndeclaredStatuses: map[int]int{500: 1},
+	}},
+	Unmatched: []*UnmatchedRoute{{Method: "GET", Path: "/owners/1", Reason: "path not found", Entries: 1}},
+}
+
+func TestWriteText(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := WriteText(&buf, testReport)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range []string{
+		"GET /pets/{id} (getPet): 3 entries, 1 passed\n",
+		"  entry 1 http://localhost/pets/abc: request: path id: value must be an integer\n",
+		"  undeclared status 500: 1 entries\n",
+		"  GET /owners/1: 1 entries (path not found)\n",
+		"4 entries, 1 operations, 1 violations, 1 undeclared statuses, 1 unmatched routes\n",
+	} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("expected the output to contain %q, got:\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := WriteJSON(&buf, testReport)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+	if decoded.Entries != 4 || decoded.Operations[0].UndeclaredStatuses[500] != 1 || decoded.Unmatched[0].Path != "/owners/1" {
+		t.Errorf("expected the report to round-trip, got %+v", decoded)
+	}
+}
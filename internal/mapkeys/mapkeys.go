@@ -0,0 +1,12 @@
+// Package mapkeys holds the map helpers shared by the packages of the module.
+package mapkeys
+
+import (
+	"maps"
+	"slices"
+)
+
+// Sorted returns the keys of m in ascending order, for deterministic iteration.
+func Sorted[M ~map[string]V, V any](m M) []string {
+	return slices.Sorted(maps.Keys(m))
+}
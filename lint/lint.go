@@ -0,0 +1,324 @@
+// Package lint checks OpenAPI specs before they are handed to the validator. Lint loads a spec the
+// way the validator does, with external $refs, and reports load and validation errors together with
+// the findings of opt-in style rules, positioned by file, line and column.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	validator "github.com/vihuvac/go-openapi-validator"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the importance of a Finding.
+type Severity string
+
+const (
+	// SeverityError marks specs the validator cannot load.
+	SeverityError Severity = "error"
+	// SeverityWarning marks findings of the opt-in rules.
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a single problem found in a spec.
+type Finding struct {
+	// Rule is the rule that produced the finding.
+	Rule Rule `json:"rule"`
+	// Severity is SeverityError for invalid specs and SeverityWarning otherwise.
+	Severity Severity `json:"severity"`
+	// Message describes the problem.
+	Message string `json:"message"`
+	// File is the spec file the finding was reported for.
+	File string `json:"file"`
+	// Line and Column locate the finding in File, starting at 1. They are zero when unknown.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	// Pointer is the JSON pointer of the offending element in the root document, if any.
+	Pointer string `json:"pointer,omitempty"`
+}
+
+// Option is a function type used to configure Lint.
+type Option func(*config)
+
+type config struct {
+	rules []Rule
+}
+
+// WithRules returns an Option that enables the given opt-in rules in addition to spec validation.
+func WithRules(rules ...Rule) Option {
+	return func(c *config) {
+		c.rules = append(c.rules, rules...)
+	}
+}
+
+// spec is a loaded spec together with the sources needed to position findings.
+type spec struct {
+	path string
+	doc  *openapi3.T
+	// root is the parsed root file, used to resolve JSON pointers to positions.
+	root *yaml.Node
+	// files holds the content of every file loaded, keyed by cleaned slash-separated path.
+	files map[string][]byte
+	// nodes caches the parsed files, used to position findings in referenced files.
+	nodes map[string]*yaml.Node
+}
+
+// Lint loads the spec at specPath, resolving external $refs, and returns its problems sorted by
+// file and position. Load and validation errors are always reported; the rules enabled with
+// WithRules add warnings whenever the spec loads, even if it does not validate. The returned
+// error is only set when linting could not run at all, e.g. for an unknown rule or an
+// unreadable file.
+func Lint(specPath string, opts ...Option) ([]Finding, error) {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	for _, rule := range cfg.rules {
+		if _, ok := ruleChecks[rule]; !ok {
+			return nil, fmt.Errorf("unknown rule %q", rule)
+		}
+	}
+
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return []Finding{specFinding(specPath, err)}, nil
+	}
+
+	s := &spec{path: filepath.Clean(specPath), root: &root, files: make(map[string][]byte), nodes: make(map[string]*yaml.Node)}
+	// Load like validator.New, so every command accepts the same specs, recording the local files read.
+	loader := validator.NewLoader()
+	read := loader.ReadFromURIFunc
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := read(loader, location)
+		if err == nil && location.Host == "" {
+			s.files[path.Clean(filepath.ToSlash(location.Path))] = data
+		}
+		return data, err
+	}
+	s.doc, err = loader.LoadFromFile(specPath)
+	if err != nil {
+		return []Finding{s.loadFinding(err)}, nil
+	}
+
+	findings := s.validate(context.Background())
+	for _, rule := range cfg.rules {
+		findings = append(findings, ruleChecks[rule](s)...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return findings, nil
+}
+
+// lineRE extracts the line number from YAML and JSON syntax errors.
+var lineRE = regexp.MustCompile(`line (\d+)`)
+
+// specFinding reports a spec that failed to load, positioned from the error message when possible.
+func specFinding(file string, err error) Finding {
+	f := Finding{Rule: RuleInvalidSpec, Severity: SeverityError, Message: err.Error(), File: file}
+	if m := lineRE.FindStringSubmatch(err.Error()); m != nil {
+		f.Line, _ = strconv.Atoi(m[1])
+	}
+	return f
+}
+
+// loadFinding reports a spec that failed to load, in the referenced file that does not parse if
+// there is one.
+func (s *spec) loadFinding(err error) Finding {
+	root := path.Clean(filepath.ToSlash(s.path))
+	for _, file := range mapkeys.Sorted(s.files) {
+		if file != root && s.node(file) == nil {
+			return specFinding(filepath.FromSlash(file), err)
+		}
+	}
+	return specFinding(s.path, err)
+}
+
+// validate validates the document like the validator does. When it is invalid, the paths,
+// components and top-level sections are validated one by one so that every error is reported
+// at the element it comes from.
+func (s *spec) validate(ctx context.Context) []Finding {
+	err := s.doc.Validate(ctx)
+	if err == nil {
+		return nil
+	}
+
+	var findings []Finding
+	report := func(pointer string, err error) {
+		findings = append(findings, s.finding(RuleInvalidSpec, SeverityError, pointer, err.Error()))
+	}
+
+	if s.doc.Info == nil {
+		report("", fmt.Errorf("must have an info object"))
+	} else if err := s.doc.Info.Validate(ctx); err != nil {
+		report("/info", err)
+	}
+	if err := s.doc.Servers.Validate(ctx); err != nil {
+		report("/servers", err)
+	}
+
+	if s.doc.Paths != nil {
+		for _, p := range mapkeys.Sorted(s.doc.Paths.Map()) {
+			item := s.doc.Paths.Value(p)
+			if err := openapi3.NewPaths(openapi3.WithPath(p, item)).Validate(ctx); err == nil {
+				continue
+			} else if !s.validateOperations(ctx, p, item, report) {
+				report(pointer("paths", p), err)
+			}
+		}
+	}
+
+	if c := s.doc.Components; c != nil {
+		for _, e := range componentEntries(c) {
+			if err := e.components.Validate(ctx); err != nil {
+				report(pointer("components", e.kind, e.name), err)
+			}
+		}
+	}
+
+	if len(findings) == 0 {
+		report("", err)
+	}
+	return findings
+}
+
+// validateOperations reports the invalid operations of a path item, returning whether there were any.
+func (s *spec) validateOperations(ctx context.Context, p string, item *openapi3.PathItem, report func(string, error)) bool {
+	found := false
+	for _, method := range mapkeys.Sorted(item.Operations()) {
+		if err := item.Operations()[method].Validate(ctx); err != nil {
+			report(pointer("paths", p, strings.ToLower(method)), err)
+			found = true
+		}
+	}
+	return found
+}
+
+// componentEntry is a single named component, wrapped in its own Components for validation.
+type componentEntry struct {
+	kind, name string
+	components *openapi3.Components
+}
+
+// componentEntries returns every component declared in c.
+func componentEntries(c *openapi3.Components) []componentEntry {
+	var entries []componentEntry
+	add := func(kind string, names []string, one func(name string) *openapi3.Components) {
+		for _, name := range names {
+			entries = append(entries, componentEntry{kind: kind, name: name, components: one(name)})
+		}
+	}
+	add("schemas", mapkeys.Sorted(c.Schemas), func(n string) *openapi3.Components {
+		return &openapi3.Components{Schemas: openapi3.Schemas{n: c.Schemas[n]}}
+	})
+	add("parameters", mapkeys.Sorted(c.Parameters), func(n string) *openapi3.Components {
+		return &openapi3.Components{Parameters: openapi3.ParametersMap{n: c.Parameters[n]}}
+	})
+	add("headers", mapkeys.Sorted(c.Headers), func(n string) *openapi3.Components {
+		return &openapi3.Components{Headers: openapi3.Headers{n: c.Headers[n]}}
+	})
+	add("requestBodies", mapkeys.Sorted(c.RequestBodies), func(n string) *openapi3.Components {
+		return &openapi3.Components{RequestBodies: openapi3.RequestBodies{n: c.RequestBodies[n]}}
+	})
+	add("responses", mapkeys.Sorted(c.Responses), func(n string) *openapi3.Components {
+		return &openapi3.Components{Responses: openapi3.ResponseBodies{n: c.Responses[n]}}
+	})
+	add("securitySchemes", mapkeys.Sorted(c.SecuritySchemes), func(n string) *openapi3.Components {
+		return &openapi3.Components{SecuritySchemes: openapi3.SecuritySchemes{n: c.SecuritySchemes[n]}}
+	})
+	add("examples", mapkeys.Sorted(c.Examples), func(n string) *openapi3.Components {
+		return &openapi3.Components{Examples: openapi3.Examples{n: c.Examples[n]}}
+	})
+	add("links", mapkeys.Sorted(c.Links), func(n string) *openapi3.Components {
+		return &openapi3.Components{Links: openapi3.Links{n: c.Links[n]}}
+	})
+	add("callbacks", mapkeys.Sorted(c.Callbacks), func(n string) *openapi3.Components {
+		return &openapi3.Components{Callbacks: openapi3.Callbacks{n: c.Callbacks[n]}}
+	})
+	return entries
+}
+
+// finding returns a Finding positioned at the element pointer refers to in the root document.
+// Validation errors come from the element itself, so a reference at the element is followed for
+// them, while rule findings are reported where the element is declared.
+func (s *spec) finding(rule Rule, severity Severity, ptr, message string) Finding {
+	file, line, column := s.locate(ptr, rule == RuleInvalidSpec)
+	return Finding{
+		Rule:     rule,
+		Severity: severity,
+		Message:  message,
+		File:     file,
+		Line:     line,
+		Column:   column,
+		Pointer:  ptr,
+	}
+}
+
+// maxRefHops bounds how many references locate follows, in case they form a cycle.
+const maxRefHops = 32
+
+// locate returns the file, line and column of the element at ptr in the root document, following
+// the $refs to other files of the spec on the way. A $ref at the element itself is only followed
+// when resolve is set. Local $refs are not followed.
+func (s *spec) locate(ptr string, resolve bool) (file string, line, column int) {
+	file, node, toks := path.Clean(filepath.ToSlash(s.path)), s.root, tokens(ptr)
+	for range maxRefHops {
+		var ref *yaml.Node
+		line, column, ref, toks = position(node, toks)
+		if ref == nil || (len(toks) == 0 && !resolve) {
+			break
+		}
+		_, value := mappingEntry(ref, "$ref")
+		target, fragment, _ := strings.Cut(value.Value, "#")
+		if target == "" {
+			break
+		}
+		target = path.Join(path.Dir(file), target)
+		root := s.node(target)
+		if root == nil {
+			break
+		}
+		file, node, toks = target, root, append(tokens(fragment), toks...)
+	}
+	return filepath.FromSlash(file), line, column
+}
+
+// node returns the parsed content of a file loaded with the spec, or nil if it was not loaded.
+func (s *spec) node(file string) *yaml.Node {
+	if node, ok := s.nodes[file]; ok {
+		return node
+	}
+	var node *yaml.Node
+	if data, ok := s.files[file]; ok {
+		node = &yaml.Node{}
+		if yaml.Unmarshal(data, node) != nil {
+			node = nil
+		}
+	}
+	s.nodes[file] = node
+	return node
+}
@@ -0,0 +1,178 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSpec writes files, keyed by name, to a temporary directory and returns the path of the first one.
+func writeSpec(t *testing.T, name, content string, others ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := append([]string{name, content}, others...)
+	for i := 0; i+1 < len(files); i += 2 {
+		if err := os.WriteFile(filepath.Join(dir, files[i]), []byte(files[i+1]), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", files[i], err)
+		}
+	}
+	return filepath.Join(dir, name)
+}
+
+const testLintSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas.yaml#/Pets'
+              example: []
+        default:
+          description: Error
+`
+
+const testLintSchemas = `Pets:
+  type: array
+  items: {type: string}
+`
+
+func TestLint(t *testing.T) {
+	t.Run("Valid Spec", func(t *testing.T) {
+		// Arrange
+		specPath := writeSpec(t, "openapi.yaml", testLintSpec, "schemas.yaml", testLintSchemas)
+
+		// Act
+		findings, err := Lint(specPath, WithRules(OptionalRules()...))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(findings) != 0 {
+			t.Errorf("expected no findings, got %+v", findings)
+		}
+	})
+
+	t.Run("Missing External Ref", func(t *testing.T) {
+		// Arrange
+		specPath := writeSpec(t, "openapi.yaml", testLintSpec)
+
+		// Act
+		findings, err := Lint(specPath)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(findings) != 1 || findings[0].Rule != RuleInvalidSpec || findings[0].Severity != SeverityError {
+			t.Errorf("expected one invalid-spec error, got %+v", findings)
+		}
+	})
+
+	t.Run("Syntax Error", func(t *testing.T) {
+		// Arrange
+		specPath := writeSpec(t, "openapi.yaml", "openapi: 3.0.0\ninfo:\n  title: [\n")
+
+		// Act
+		findings, err := Lint(specPath)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(findings) != 1 || findings[0].Line == 0 {
+			t.Errorf("expected one positioned finding, got %+v", findings)
+		}
+	})
+
+	t.Run("Syntax Error In Referenced File", func(t *testing.T) {
+		// Arrange
+		specPath := writeSpec(t, "openapi.yaml", testLintSpec, "schemas.yaml", "Pets:\n  type: [\n")
+
+		// Act
+		findings, err := Lint(specPath)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := filepath.Join(filepath.Dir(specPath), "schemas.yaml")
+		if len(findings) != 1 || findings[0].File != want || findings[0].Line == 0 {
+			t.Errorf("expected one positioned finding in %s, got %+v", want, findings)
+		}
+	})
+
+	t.Run("Validation Errors Are Positioned", func(t *testing.T) {
+		// Arrange
+		// The path parameter is not part of the path, and the schema type does not exist.
+		spec := strings.Replace(testLintSpec, "      responses:\n",
+			"      parameters:\n        - {name: id, in: path, required: true, schema: {type: string}}\n      responses:\n", 1)
+		spec += "components:\n  schemas:\n    Broken:\n      type: strin\n"
+		specPath := writeSpec(t, "openapi.yaml", spec, "schemas.yaml", testLintSchemas)
+
+		// Act
+		findings, err := Lint(specPath)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(findings) != 2 {
+			t.Fatalf("expected two findings, got %+v", findings)
+		}
+		if findings[0].Pointer != "/paths/~1pets" || findings[0].Line != 6 || findings[0].Column != 3 {
+			t.Errorf("expected the path error at 6:3, got %+v", findings[0])
+		}
+		if findings[1].Pointer != "/components/schemas/Broken" || findings[1].Line != 23 {
+			t.Errorf("expected the schema error at line 23, got %+v", findings[1])
+		}
+	})
+
+	t.Run("Errors In Referenced Files", func(t *testing.T) {
+		// Arrange
+		spec := testLintSpec + "components:\n  schemas:\n    Broken:\n      $ref: 'schemas.yaml#/Broken'\n"
+		schemas := testLintSchemas + "Broken:\n  type: object\n  properties:\n    name: {type: strin}\n"
+		specPath := writeSpec(t, "openapi.yaml", spec, "schemas.yaml", schemas)
+
+		// Act
+		findings, err := Lint(specPath)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := filepath.Join(filepath.Dir(specPath), "schemas.yaml")
+		if len(findings) != 1 || findings[0].File != want || findings[0].Line != 4 || findings[0].Column != 1 {
+			t.Errorf("expected the schema error at %s:4:1, got %+v", want, findings)
+		}
+	})
+
+	t.Run("Unknown Rule", func(t *testing.T) {
+		// Act
+		_, err := Lint("openapi.yaml", WithRules("bogus"))
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error for an unknown rule")
+		}
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		// Act
+		_, err := Lint(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error for a missing file")
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pointer builds a JSON pointer (RFC 6901) from unescaped reference tokens.
+func pointer(tokens ...string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+	}
+	return b.String()
+}
+
+// tokens splits a JSON pointer into its unescaped reference tokens.
+func tokens(ptr string) []string {
+	if ptr == "" {
+		return nil
+	}
+	toks := strings.Split(strings.TrimPrefix(ptr, "/"), "/")
+	for i, t := range toks {
+		toks[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return toks
+}
+
+// position walks the reference tokens from the parsed document root and returns the line and
+// column of the element they lead to. When the walk reaches a $ref, it stops there and returns the
+// reference node with the tokens left to walk, so that the caller can follow it. When the element
+// does not exist, the position of the closest element on the way is returned.
+func position(root *yaml.Node, toks []string) (line, column int, ref *yaml.Node, rest []string) {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line, column = node.Line, node.Column
+
+	for i, token := range toks {
+		if isRef(node) {
+			return line, column, node, toks[i:]
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			key, value := mappingEntry(node, token)
+			if key == nil {
+				return line, column, nil, nil
+			}
+			// Point at the key, where editors show the element.
+			line, column, node = key.Line, key.Column, value
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return line, column, nil, nil
+			}
+			node = node.Content[i]
+			line, column = node.Line, node.Column
+		default:
+			return line, column, nil, nil
+		}
+	}
+	if isRef(node) {
+		return line, column, node, nil
+	}
+	return line, column, nil, nil
+}
+
+// mappingEntry returns the key and value nodes of the entry named key in a mapping node.
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// isRef reports whether node is a reference object.
+func isRef(node *yaml.Node) bool {
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	key, _ := mappingEntry(node, "$ref")
+	return key != nil
+}
+
+// refs calls fn with the value of every $ref in node.
+func refs(node *yaml.Node, fn func(ref string)) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" && node.Content[i+1].Kind == yaml.ScalarNode {
+				fn(node.Content[i+1].Value)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		refs(child, fn)
+	}
+}
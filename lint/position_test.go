@@ -0,0 +1,48 @@
+package lint
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestPosition(t *testing.T) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(`paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        '200':
+          $ref: '#/components/responses/Pet'
+`), &root); err != nil {
+		t.Fatalf("failed to parse YAML: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		pointer      string
+		line, column int
+	}{
+		{"Document", "", 1, 1},
+		{"Escaped Key", pointer("paths", "/pets/{id}"), 2, 3},
+		{"Sequence Item", "/paths/~1pets~1{id}/get/parameters/0", 5, 11},
+		{"Through Reference", "/paths/~1pets~1{id}/get/responses/200/description", 8, 9},
+		{"Missing Element", "/paths/~1pets~1{id}/post", 2, 3},
+		{"Out Of Range", "/paths/~1pets~1{id}/get/parameters/5", 4, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			line, column, _, _ := position(&root, tokens(tt.pointer))
+
+			// Assert
+			if line != tt.line || column != tt.column {
+				t.Errorf("expected %d:%d, got %d:%d", tt.line, tt.column, line, column)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+var testFindings = []Finding{
+	{Rule: RuleInvalidSpec, Severity: SeverityError, Message: "broken", File: "openapi.yaml"},
+	{Rule: RuleMissingOperationID, Severity: SeverityWarning, Message: "no operationId", File: "openapi.yaml", Line: 7, Column: 5, Pointer: "/paths/~1pets/get"},
+}
+
+func TestWriteText(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := WriteText(&buf, testFindings)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "openapi.yaml: error: broken (invalid-spec)\n" +
+		"openapi.yaml:7:5: warning: no operationId (missing-operation-id)\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Run("Findings", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		err := WriteJSON(&buf, testFindings)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var decoded []Finding
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("failed to decode output: %v", err)
+		}
+		if len(decoded) != 2 || decoded[1] != testFindings[1] {
+			t.Errorf("expected the findings to round-trip, got %+v", decoded)
+		}
+	})
+
+	t.Run("No Findings", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		WriteJSON(&buf, nil)
+
+		// Assert
+		if buf.String() != "[]\n" {
+			t.Errorf("expected an empty array, got %q", buf.String())
+		}
+	})
+}
+
+func TestWriteSARIF(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := WriteSARIF(&buf, testFindings)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("failed to decode output: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("expected a single SARIF 2.1.0 run, got %+v", log)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 2 || results[0].Level != "error" || results[1].RuleID != "missing-operation-id" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if results[0].Locations[0].PhysicalLocation.Region != nil {
+		t.Error("expected no region for an unpositioned finding")
+	}
+	if region := results[1].Locations[0].PhysicalLocation.Region; region == nil || region.StartLine != 7 {
+		t.Errorf("expected the region to start at line 7, got %+v", region)
+	}
+}
@@ -0,0 +1,208 @@
+package lint
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule identifies a lint check.
+type Rule string
+
+const (
+	// RuleInvalidSpec reports specs that fail to load or validate. It always runs.
+	RuleInvalidSpec Rule = "invalid-spec"
+	// RuleMissingOperationID reports operations without an operationId.
+	RuleMissingOperationID Rule = "missing-operation-id"
+	// RuleUndocumented4xx reports operations declaring neither a 4xx nor a default response.
+	RuleUndocumented4xx Rule = "undocumented-4xx"
+	// RuleUnusedComponent reports components that are never referenced.
+	RuleUnusedComponent Rule = "unused-component"
+	// RuleMissingExample reports request and response media types without an example.
+	RuleMissingExample Rule = "missing-example"
+)
+
+// ruleChecks maps the opt-in rules to their checks.
+var ruleChecks = map[Rule]func(*spec) []Finding{
+	RuleMissingOperationID: checkOperationIDs,
+	RuleUndocumented4xx:    check4xxResponses,
+	RuleUnusedComponent:    checkUnusedComponents,
+	RuleMissingExample:     checkExamples,
+}
+
+// OptionalRules returns the opt-in rules, in the order they are documented.
+func OptionalRules() []Rule {
+	return []Rule{RuleMissingOperationID, RuleUndocumented4xx, RuleUnusedComponent, RuleMissingExample}
+}
+
+// Description returns a one-line description of the rule.
+func (r Rule) Description() string {
+	switch r {
+	case RuleInvalidSpec:
+		return "The spec must load and validate."
+	case RuleMissingOperationID:
+		return "Operations should have an operationId."
+	case RuleUndocumented4xx:
+		return "Operations should document their client error (4xx) responses."
+	case RuleUnusedComponent:
+		return "Components should be referenced."
+	case RuleMissingExample:
+		return "Request and response media types should have an example."
+	}
+	return ""
+}
+
+// operation is an operation of the spec with its JSON pointer.
+type operation struct {
+	method, path, pointer string
+	op                    *openapi3.Operation
+}
+
+// operations returns the operations of the spec, sorted by path and method.
+func (s *spec) operations() []operation {
+	var ops []operation
+	if s.doc.Paths == nil {
+		return nil
+	}
+	for _, p := range mapkeys.Sorted(s.doc.Paths.Map()) {
+		item := s.doc.Paths.Value(p)
+		if item == nil {
+			continue
+		}
+		for _, method := range mapkeys.Sorted(item.Operations()) {
+			ops = append(ops, operation{
+				method:  method,
+				path:    p,
+				pointer: pointer("paths", p, strings.ToLower(method)),
+				op:      item.Operations()[method],
+			})
+		}
+	}
+	return ops
+}
+
+func checkOperationIDs(s *spec) []Finding {
+	var findings []Finding
+	for _, o := range s.operations() {
+		if o.op.OperationID == "" {
+			findings = append(findings, s.finding(RuleMissingOperationID, SeverityWarning, o.pointer,
+				fmt.Sprintf("operation %s %s has no operationId", o.method, o.path)))
+		}
+	}
+	return findings
+}
+
+func check4xxResponses(s *spec) []Finding {
+	var findings []Finding
+	for _, o := range s.operations() {
+		if o.op.Responses == nil {
+			continue
+		}
+		documented := false
+		for code := range o.op.Responses.Map() {
+			if code == "default" || strings.HasPrefix(code, "4") {
+				documented = true
+				break
+			}
+		}
+		if !documented {
+			findings = append(findings, s.finding(RuleUndocumented4xx, SeverityWarning, o.pointer+"/responses",
+				fmt.Sprintf("operation %s %s documents no 4xx or default response", o.method, o.path)))
+		}
+	}
+	return findings
+}
+
+func checkUnusedComponents(s *spec) []Finding {
+	if s.doc.Components == nil {
+		return nil
+	}
+
+	// Collect the local fragments referenced from any file of the spec.
+	used := make(map[string]bool)
+	rootPath := path.Clean(filepath.ToSlash(s.path))
+	for file, data := range s.files {
+		var node yaml.Node
+		if yaml.Unmarshal(data, &node) != nil {
+			continue
+		}
+		refs(&node, func(ref string) {
+			target, fragment, _ := strings.Cut(ref, "#")
+			if target != "" {
+				target = path.Join(path.Dir(file), target)
+			} else {
+				target = file
+			}
+			if path.Clean(target) == rootPath {
+				used[fragment] = true
+			}
+		})
+	}
+
+	// Security schemes are referenced by name from security requirements.
+	requirements := []openapi3.SecurityRequirements{s.doc.Security}
+	for _, o := range s.operations() {
+		if o.op.Security != nil {
+			requirements = append(requirements, *o.op.Security)
+		}
+	}
+	for _, reqs := range requirements {
+		for _, req := range reqs {
+			for name := range req {
+				used[pointer("components", "securitySchemes", name)] = true
+			}
+		}
+	}
+
+	var findings []Finding
+	for _, e := range componentEntries(s.doc.Components) {
+		ptr := pointer("components", e.kind, e.name)
+		if !used[ptr] {
+			findings = append(findings, s.finding(RuleUnusedComponent, SeverityWarning, ptr,
+				fmt.Sprintf("component %s is never referenced", "#"+ptr)))
+		}
+	}
+	return findings
+}
+
+func checkExamples(s *spec) []Finding {
+	var findings []Finding
+	check := func(content openapi3.Content, ptr, what string) {
+		for _, mediaType := range mapkeys.Sorted(content) {
+			mt := content[mediaType]
+			if mt == nil || hasExample(mt) {
+				continue
+			}
+			findings = append(findings, s.finding(RuleMissingExample, SeverityWarning, ptr+pointer("content", mediaType),
+				fmt.Sprintf("%s %s has no example", what, mediaType)))
+		}
+	}
+
+	for _, o := range s.operations() {
+		if body := o.op.RequestBody; body != nil && body.Value != nil {
+			check(body.Value.Content, o.pointer+"/requestBody", fmt.Sprintf("request body of %s %s", o.method, o.path))
+		}
+		if o.op.Responses == nil {
+			continue
+		}
+		for _, code := range mapkeys.Sorted(o.op.Responses.Map()) {
+			if resp := o.op.Responses.Value(code); resp != nil && resp.Value != nil {
+				check(resp.Value.Content, o.pointer+pointer("responses", code), fmt.Sprintf("%s response of %s %s", code, o.method, o.path))
+			}
+		}
+	}
+	return findings
+}
+
+// hasExample reports whether a media type has an example of its own or through its schema.
+func hasExample(mt *openapi3.MediaType) bool {
+	if mt.Example != nil || len(mt.Examples) > 0 {
+		return true
+	}
+	return mt.Schema != nil && mt.Schema.Value != nil && mt.Schema.Value.Example != nil
+}
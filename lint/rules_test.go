@@ -0,0 +1,110 @@
+package lint
+
+import (
+	"testing"
+)
+
+const testRulesSpec = `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pets'
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              example: {name: Rex}
+      responses:
+        '201':
+          description: Created
+        '4XX':
+          description: Client error
+components:
+  schemas:
+    Pets:
+      type: array
+      items: {$ref: 'schemas.yaml#/Pet'}
+    Unused:
+      type: string
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    basic:
+      type: http
+      scheme: basic
+`
+
+const testRulesSchemas = `Pet:
+  type: object
+  properties:
+    name: {type: string}
+`
+
+func TestRules(t *testing.T) {
+	specPath := writeSpec(t, "openapi.yaml", testRulesSpec, "schemas.yaml", testRulesSchemas)
+
+	tests := []struct {
+		rule     Rule
+		pointers []string
+	}{
+		{RuleMissingOperationID, []string{"/paths/~1pets/get"}},
+		{RuleUndocumented4xx, []string{"/paths/~1pets/get/responses"}},
+		{RuleUnusedComponent, []string{"/components/schemas/Unused", "/components/securitySchemes/basic"}},
+		{RuleMissingExample, []string{"/paths/~1pets/get/responses/200/content/application~1json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.rule), func(t *testing.T) {
+			// Act
+			findings, err := Lint(specPath, WithRules(tt.rule))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(findings) != len(tt.pointers) {
+				t.Fatalf("expected %d findings, got %+v", len(tt.pointers), findings)
+			}
+			for i, f := range findings {
+				if f.Rule != tt.rule || f.Severity != SeverityWarning || f.Pointer != tt.pointers[i] {
+					t.Errorf("expected a %s warning at %s, got %+v", tt.rule, tt.pointers[i], f)
+				}
+				if f.Line == 0 {
+					t.Errorf("expected a positioned finding, got %+v", f)
+				}
+			}
+		})
+	}
+}
+
+func TestRulesForInvalidSpec(t *testing.T) {
+	// Arrange
+	specPath := writeSpec(t, "openapi.yaml", "openapi: 3.0.0\ninfo: {title: Test API, version: 1.0.0}\npaths:\n  /pets:\n    get: {}\n")
+
+	// Act
+	findings, err := Lint(specPath, WithRules(OptionalRules()...))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 || findings[0].Rule != RuleInvalidSpec || findings[1].Rule != RuleMissingOperationID {
+		t.Errorf("expected the validation error and the rule findings, got %+v", findings)
+	}
+}
@@ -0,0 +1,401 @@
+package openapi_validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// ErrNoMockResponse is reported, with status 400, when the Prefer header of a mock request asks
+// for a response code or example the operation does not declare.
+var ErrNoMockResponse = errors.New("no mock response matches the request preferences")
+
+// maxMockDepth bounds schema synthesis for recursive schemas.
+const maxMockDepth = 8
+
+// MockHandler returns an http.Handler answering every operation of the spec with mock responses.
+// Requests go through Middleware first, so they are routed and validated like real ones.
+//
+// The response is the lowest declared 2xx response (or default) unless the request carries a
+// Prefer header such as "Prefer: code=404, example=notFound". Its body is the named example,
+// the media type's example, its first example by name, or a value synthesized from the schema,
+// in that order. The media type is negotiated with the Accept header, preferring JSON.
+func (v *Validator) MockHandler() http.Handler {
+	return v.Middleware(http.HandlerFunc(v.serveMock))
+}
+
+// serveMock writes the mock response of the operation matched by Middleware.
+func (v *Validator) serveMock(w http.ResponseWriter, r *http.Request) {
+	op, ok := OperationFromContext(r.Context())
+	if !ok {
+		v.encodeError(w, r, routers.ErrPathNotFound)
+		return
+	}
+
+	prefer := parsePrefer(r.Header.Values("Prefer"))
+	status, response, err := mockResponse(op.Operation, prefer["code"])
+	if err != nil {
+		v.Options.ErrorEncoder(w, r, &HTTPError{Status: http.StatusBadRequest, Err: err})
+		return
+	}
+
+	var applied []string
+	if prefer["code"] != "" {
+		applied = append(applied, "code="+prefer["code"])
+	}
+
+	for _, name := range mapkeys.Sorted(response.Headers) {
+		header := response.Headers[name]
+		if header == nil || header.Value == nil {
+			continue
+		}
+		if value, ok := mockHeaderValue(header.Value); ok {
+			w.Header().Set(name, value)
+		}
+	}
+
+	mediaType, media := negotiateMedia(response.Content, r.Header.Get("Accept"))
+	if media == nil || status == http.StatusNoContent || status == http.StatusNotModified {
+		setPreferenceApplied(w, applied)
+		w.WriteHeader(status)
+		return
+	}
+
+	body, err := mockBody(media, prefer["example"])
+	if err != nil {
+		v.Options.ErrorEncoder(w, r, &HTTPError{Status: http.StatusBadRequest, Err: err})
+		return
+	}
+	if prefer["example"] != "" {
+		applied = append(applied, "example="+prefer["example"])
+	}
+
+	data, err := encodeMockBody(mediaType, body)
+	if err != nil {
+		v.Options.ErrorEncoder(w, r, &HTTPError{Status: http.StatusInternalServerError, Err: err})
+		return
+	}
+
+	setPreferenceApplied(w, applied)
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// parsePrefer parses the preferences of Prefer headers (RFC 7240) into a map.
+func parsePrefer(values []string) map[string]string {
+	prefer := make(map[string]string)
+	for _, value := range values {
+		for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+			key, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+			prefer[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(val), `"`)
+		}
+	}
+	return prefer
+}
+
+// setPreferenceApplied reports the honored preferences in the Preference-Applied header.
+func setPreferenceApplied(w http.ResponseWriter, applied []string) {
+	if len(applied) > 0 {
+		w.Header().Set("Preference-Applied", strings.Join(applied, ", "))
+	}
+}
+
+// mockResponse selects the response for the preferred status code, or the lowest 2xx response.
+func mockResponse(operation *openapi3.Operation, code string) (int, *openapi3.Response, error) {
+	responses := operation.Responses
+	if responses == nil || responses.Len() == 0 {
+		return http.StatusNoContent, &openapi3.Response{}, nil
+	}
+
+	if code != "" {
+		status, err := strconv.Atoi(code)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%w: invalid code %q", ErrNoMockResponse, code)
+		}
+		if ref := responses.Status(status); ref != nil && ref.Value != nil {
+			return status, ref.Value, nil
+		}
+		return 0, nil, fmt.Errorf("%w: no response declared for status %d", ErrNoMockResponse, status)
+	}
+
+	// Prefer the lowest success response, then default, then the lowest other response.
+	keys := responses.Map()
+	codes := mapkeys.Sorted(keys)
+	sort.SliceStable(codes, func(i, j int) bool { return mockStatus(codes[i]) < mockStatus(codes[j]) })
+	for _, key := range codes {
+		if status := mockStatus(key); status >= 200 && status < 300 && keys[key] != nil && keys[key].Value != nil {
+			return status, keys[key].Value, nil
+		}
+	}
+	if ref := responses.Default(); ref != nil && ref.Value != nil {
+		return http.StatusOK, ref.Value, nil
+	}
+	for _, key := range codes {
+		if status := mockStatus(key); status != 0 && keys[key] != nil && keys[key].Value != nil {
+			return status, keys[key].Value, nil
+		}
+	}
+	return http.StatusNoContent, &openapi3.Response{}, nil
+}
+
+// mockStatus returns the status code of a response key, using the lowest code of ranges like 4XX.
+func mockStatus(key string) int {
+	if len(key) == 3 && strings.HasSuffix(strings.ToUpper(key), "XX") {
+		return int(key[0]-'0') * 100
+	}
+	status, err := strconv.Atoi(key)
+	if err != nil {
+		return 0
+	}
+	return status
+}
+
+// negotiateMedia picks the media type matching the Accept header, preferring JSON.
+func negotiateMedia(content openapi3.Content, accept string) (string, *openapi3.MediaType) {
+	if len(content) == 0 {
+		return "", nil
+	}
+
+	types := mapkeys.Sorted(content)
+	sort.SliceStable(types, func(i, j int) bool {
+		return isJSONMediaType(types[i]) && !isJSONMediaType(types[j])
+	})
+
+	for _, part := range strings.Split(accept, ",") {
+		want, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || want == "*/*" {
+			continue
+		}
+		for _, mediaType := range types {
+			if matchesMediaRange(mediaType, want) {
+				return concreteMediaType(mediaType), content[mediaType]
+			}
+		}
+	}
+	return concreteMediaType(types[0]), content[types[0]]
+}
+
+// matchesMediaRange reports whether mediaType falls within the media range want, either of which may use wildcards.
+func matchesMediaRange(mediaType, want string) bool {
+	mt, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return false
+	}
+	mtType, mtSub, _ := strings.Cut(mt, "/")
+	wantType, wantSub, _ := strings.Cut(want, "/")
+	return (mtType == wantType || mtType == "*" || wantType == "*") && (mtSub == wantSub || mtSub == "*" || wantSub == "*")
+}
+
+// concreteMediaType replaces the wildcards of a declared media range such as application/*.
+func concreteMediaType(mediaType string) string {
+	switch {
+	case mediaType == "*/*":
+		return "application/json"
+	case strings.HasSuffix(mediaType, "/*"):
+		return strings.TrimSuffix(mediaType, "*") + "octet-stream"
+	}
+	return mediaType
+}
+
+// mockBody returns the named example, the declared example, the first example or a synthesized value.
+func mockBody(media *openapi3.MediaType, name string) (any, error) {
+	if name != "" {
+		if example := media.Examples[name]; example != nil && example.Value != nil {
+			return example.Value.Value, nil
+		}
+		return nil, fmt.Errorf("%w: no example named %q", ErrNoMockResponse, name)
+	}
+	if media.Example != nil {
+		return media.Example, nil
+	}
+	for _, key := range mapkeys.Sorted(media.Examples) {
+		if example := media.Examples[key]; example != nil && example.Value != nil {
+			return example.Value.Value, nil
+		}
+	}
+	if media.Schema != nil {
+		return synthesize(media.Schema.Value, false, 0), nil
+	}
+	return nil, nil
+}
+
+// encodeMockBody encodes a mock body as JSON, or as plain text for non-JSON media types.
+func encodeMockBody(mediaType string, body any) ([]byte, error) {
+	if !isJSONMediaType(mediaType) {
+		if s, ok := body.(string); ok {
+			return []byte(s), nil
+		}
+	}
+	return json.Marshal(body)
+}
+
+// mockHeaderValue returns the example or synthesized value of a required or exemplified header.
+func mockHeaderValue(header *openapi3.Header) (string, bool) {
+	if header.Example != nil {
+		return fmt.Sprint(header.Example), true
+	}
+	if !header.Required || header.Schema == nil {
+		return "", false
+	}
+	value := synthesize(header.Schema.Value, false, 0)
+	if value == nil {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+// SampleRequestValue returns a value satisfying schema for use in a request, built the way
+// MockHandler builds response bodies: the schema's example, default or first enum value, or a
+// value synthesized from its type and constraints. readOnly properties are left out.
+func SampleRequestValue(schema *openapi3.Schema) any {
+	return synthesize(schema, true, 0)
+}
+
+// synthesize builds a value satisfying schema from its example, default, enum or type,
+// leaving out the properties that may not appear in a request or a response.
+func synthesize(schema *openapi3.Schema, request bool, depth int) any {
+	if schema == nil || depth > maxMockDepth {
+		return nil
+	}
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	}
+
+	if len(schema.AllOf) > 0 {
+		merged := make(map[string]any)
+		for _, sub := range schema.AllOf {
+			if sub == nil {
+				continue
+			}
+			value := synthesize(sub.Value, request, depth+1)
+			object, ok := value.(map[string]any)
+			if !ok {
+				return value
+			}
+			for k, val := range object {
+				merged[k] = val
+			}
+		}
+		if len(schema.Properties) > 0 {
+			for k, val := range synthesizeObject(schema, request, depth) {
+				merged[k] = val
+			}
+		}
+		return merged
+	}
+	for _, alternatives := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf} {
+		if len(alternatives) > 0 && alternatives[0] != nil {
+			return synthesize(alternatives[0].Value, request, depth+1)
+		}
+	}
+
+	switch {
+	case schemaIs(schema, openapi3.TypeObject) || (schema.Type == nil && len(schema.Properties) > 0):
+		return synthesizeObject(schema, request, depth)
+	case schemaIs(schema, openapi3.TypeArray):
+		count := max(int(schema.MinItems), 1)
+		var itemSchema *openapi3.Schema
+		if schema.Items != nil {
+			itemSchema = schema.Items.Value
+		}
+		items := make([]any, count)
+		for i := range items {
+			items[i] = synthesize(itemSchema, request, depth+1)
+		}
+		return items
+	case schemaIs(schema, openapi3.TypeString):
+		return synthesizeString(schema)
+	case schemaIs(schema, openapi3.TypeInteger):
+		return int64(synthesizeNumber(schema, 1))
+	case schemaIs(schema, openapi3.TypeNumber):
+		return synthesizeNumber(schema, 0.5)
+	case schemaIs(schema, openapi3.TypeBoolean):
+		return true
+	}
+	return nil
+}
+
+// synthesizeObject synthesizes every property of an object schema that may appear in a request or a response.
+func synthesizeObject(schema *openapi3.Schema, request bool, depth int) map[string]any {
+	object := make(map[string]any, len(schema.Properties))
+	for _, name := range mapkeys.Sorted(schema.Properties) {
+		prop := schema.Properties[name]
+		if prop == nil || prop.Value == nil || (request && prop.Value.ReadOnly) || (!request && prop.Value.WriteOnly) {
+			continue
+		}
+		object[name] = synthesize(prop.Value, request, depth+1)
+	}
+	return object
+}
+
+// synthesizeString returns a sample value for the format of a string schema, sized to its length bounds.
+func synthesizeString(schema *openapi3.Schema) string {
+	switch schema.Format {
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "date":
+		return "2024-01-01"
+	case "time":
+		return "00:00:00"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "00000000-0000-4000-8000-000000000000"
+	case "uri", "url":
+		return "https://example.com"
+	case "hostname":
+		return "example.com"
+	case "ipv4":
+		return "192.0.2.1"
+	case "ipv6":
+		return "2001:db8::1"
+	case "byte":
+		return "c3RyaW5n"
+	}
+
+	s := "string"
+	for uint64(len(s)) < schema.MinLength {
+		s += "x"
+	}
+	if schema.MaxLength != nil && uint64(len(s)) > *schema.MaxLength {
+		s = s[:*schema.MaxLength]
+	}
+	return s
+}
+
+// synthesizeNumber returns the smallest number allowed by the bounds and multipleOf of schema,
+// or fallback when it is unbounded.
+func synthesizeNumber(schema *openapi3.Schema, fallback float64) float64 {
+	value := fallback
+	if schema.Min != nil {
+		value = *schema.Min
+		if schema.ExclusiveMin {
+			value++
+		}
+	} else if schema.Max != nil && value > *schema.Max {
+		value = *schema.Max
+		if schema.ExclusiveMax {
+			value--
+		}
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		value = math.Ceil(value / *schema.MultipleOf) * *schema.MultipleOf
+	}
+	return value
+}
@@ -0,0 +1,278 @@
+package openapi_validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const testMockSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema: {type: integer, minimum: 10}
+          content:
+            application/json:
+              examples:
+                cat: {value: {name: Tom, kind: cat}}
+                dog: {value: {name: Rex, kind: dog}}
+            text/plain:
+              example: Tom
+        '404':
+          description: Not Found
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code: {type: integer, multipleOf: 5, minimum: 401}
+                  message: {type: string, minLength: 8}
+                  at: {type: string, format: date-time}
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name: {type: string}
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id: {type: integer}
+                  tags: {type: array, minItems: 2, items: {type: string, enum: [new, old]}}
+                  status: {type: string, default: pending}
+                  secret: {type: string, writeOnly: true}
+                  owner:
+                    allOf:
+                      - {type: object, properties: {name: {type: string, example: Ann}}}
+                      - {type: object, properties: {verified: {type: boolean}}}
+        '400':
+          description: Bad Request
+  /pets/{id}/photo:
+    delete:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '204':
+          description: No Content
+`
+
+func TestMockHandler(t *testing.T) {
+	v, err := NewFromBytes([]byte(testMockSpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	handler := v.MockHandler()
+
+	decode := func(t *testing.T, rec *httptest.ResponseRecorder) map[string]any {
+		t.Helper()
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to decode %q: %v", rec.Body.String(), err)
+		}
+		return body
+	}
+
+	t.Run("First Example", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/pets/1", nil))
+
+		// Assert
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+		if body := decode(t, rec); body["name"] != "Tom" {
+			t.Errorf("expected the cat example, got %v", body)
+		}
+		if rec.Header().Get("X-Rate-Limit") != "10" {
+			t.Errorf("expected a synthesized X-Rate-Limit header, got %q", rec.Header().Get("X-Rate-Limit"))
+		}
+	})
+
+	t.Run("Preferred Example", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/pets/1", nil)
+		req.Header.Set("Prefer", "example=dog")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		if body := decode(t, rec); body["name"] != "Rex" {
+			t.Errorf("expected the dog example, got %v", body)
+		}
+		if rec.Header().Get("Preference-Applied") != "example=dog" {
+			t.Errorf("unexpected Preference-Applied %q", rec.Header().Get("Preference-Applied"))
+		}
+	})
+
+	t.Run("Accept Header", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/pets/1", nil)
+		req.Header.Set("Accept", "text/plain")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Body.String() != "Tom" || rec.Header().Get("Content-Type") != "text/plain" {
+			t.Errorf("expected a text/plain example, got %q (%s)", rec.Body.String(), rec.Header().Get("Content-Type"))
+		}
+	})
+
+	t.Run("Preferred Code With Synthesized Body", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/pets/1", nil)
+		req.Header.Set("Prefer", "code=404")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d", rec.Code)
+		}
+		want := map[string]any{"code": 405.0, "message": "stringxx", "at": "2024-01-01T00:00:00Z"}
+		if body := decode(t, rec); !reflect.DeepEqual(body, want) {
+			t.Errorf("expected %v, got %v", want, body)
+		}
+	})
+
+	t.Run("Synthesized Schema", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("POST", "/pets", strings.NewReader(`{"name":"Tom"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d", rec.Code)
+		}
+		want := map[string]any{
+			"id":     1.0,
+			"tags":   []any{"new", "new"},
+			"status": "pending",
+			"owner":  map[string]any{"name": "Ann", "verified": true},
+		}
+		if body := decode(t, rec); !reflect.DeepEqual(body, want) {
+			t.Errorf("expected %v, got %v", want, body)
+		}
+	})
+
+	t.Run("No Content", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, httptest.NewRequest("DELETE", "/pets/1/photo", nil))
+
+		// Assert
+		if rec.Code != http.StatusNoContent || rec.Body.Len() != 0 {
+			t.Errorf("expected an empty 204, got %d %q", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Invalid Request", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("POST", "/pets", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Undeclared Preference", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/pets/1", nil)
+		req.Header.Set("Prefer", "code=500")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, req)
+
+		// Assert
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "status 500") {
+			t.Errorf("expected 400 mentioning status 500, got %d %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Unknown Path", func(t *testing.T) {
+		// Arrange
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/owners", nil))
+
+		// Assert
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", rec.Code)
+		}
+	})
+}
+
+func TestSampleRequestValue(t *testing.T) {
+	// Arrange
+	schema := openapi3.NewObjectSchema().
+		WithProperty("id", &openapi3.Schema{Type: &openapi3.Types{"integer"}, ReadOnly: true}).
+		WithProperty("password", &openapi3.Schema{Type: &openapi3.Types{"string"}, WriteOnly: true, MinLength: 8}).
+		WithProperty("role", openapi3.NewStringSchema().WithEnum("admin", "user"))
+
+	// Act
+	value := SampleRequestValue(schema)
+
+	// Assert
+	object, ok := value.(map[string]any)
+	if !ok {
+		t.Fatalf("expected an object, got %#v", value)
+	}
+	if _, ok := object["id"]; ok {
+		t.Error("expected readOnly properties to be left out")
+	}
+	if password, _ := object["password"].(string); len(password) < 8 {
+		t.Errorf("expected a writeOnly password of at least 8 characters, got %#v", object["password"])
+	}
+	if object["role"] != "admin" {
+		t.Errorf("expected the first enum value, got %#v", object["role"])
+	}
+}
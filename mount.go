@@ -0,0 +1,199 @@
+package openapi_validator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gorilla/mux"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// ErrNotImplemented is reported, with status 501, for operations mounted without a handler
+// when WithNotImplemented is set.
+var ErrNotImplemented = errors.New("operation not implemented")
+
+// RouteRegistrar registers handlers on a router, translating OpenAPI path templates such as
+// /users/{id} to the router's own path syntax.
+type RouteRegistrar interface {
+	Register(method, path string, handler http.Handler) error
+}
+
+// RouteRegistrarFunc is an adapter to allow the use of ordinary functions as a RouteRegistrar.
+type RouteRegistrarFunc func(method, path string, handler http.Handler) error
+
+// Register calls f(method, path, handler).
+func (f RouteRegistrarFunc) Register(method, path string, handler http.Handler) error {
+	return f(method, path, handler)
+}
+
+// ServeMuxRegistrar returns a RouteRegistrar for an http.ServeMux, registering "METHOD /path/{name}"
+// patterns. Parameter names that are not valid Go identifiers have their other characters
+// replaced with underscores for use with r.PathValue, e.g. {user-id} becomes {user_id}.
+func ServeMuxRegistrar(m *http.ServeMux) RouteRegistrar {
+	return RouteRegistrarFunc(func(method, path string, handler http.Handler) (err error) {
+		pattern, err := serveMuxPattern(path)
+		if err != nil {
+			return err
+		}
+		// ServeMux panics on conflicting patterns.
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+		m.Handle(method+" "+pattern, handler)
+		return nil
+	})
+}
+
+// MuxRegistrar returns a RouteRegistrar for a Gorilla Mux router, which shares the path
+// template syntax of OpenAPI.
+func MuxRegistrar(router *mux.Router) RouteRegistrar {
+	return RouteRegistrarFunc(func(method, path string, handler http.Handler) error {
+		return router.Handle(path, handler).Methods(method).GetError()
+	})
+}
+
+// MountOption is a function type used to configure Mount.
+type MountOption func(*mountConfig)
+
+type mountConfig struct {
+	prefix         string
+	notImplemented bool
+}
+
+// WithMountPrefix returns a MountOption that registers every operation below prefix,
+// e.g. the base path of the spec's server.
+func WithMountPrefix(prefix string) MountOption {
+	return func(c *mountConfig) {
+		c.prefix = strings.TrimSuffix(prefix, "/")
+	}
+}
+
+// WithNotImplemented returns a MountOption that registers operations without a handler with
+// one answering 501 Not Implemented through the ErrorEncoder, instead of failing.
+func WithNotImplemented() MountOption {
+	return func(c *mountConfig) {
+		c.notImplemented = true
+	}
+}
+
+// Mount registers a handler for every operation of the spec on router, which is an
+// *http.ServeMux, a *mux.Router or any RouteRegistrar (see the gin subpackage for Gin).
+// Handlers are keyed by operationId, or by "METHOD /path" for operations without one.
+// Operations are registered in matching order, most specific paths first.
+//
+// Mount fails before registering anything when an operation has no handler (unless
+// WithNotImplemented is set) or a handler matches no operation.
+func (v *Validator) Mount(router any, handlers map[string]http.Handler, opts ...MountOption) error {
+	var registrar RouteRegistrar
+	switch r := router.(type) {
+	case RouteRegistrar:
+		registrar = r
+	case *http.ServeMux:
+		registrar = ServeMuxRegistrar(r)
+	case *mux.Router:
+		registrar = MuxRegistrar(r)
+	default:
+		return fmt.Errorf("mount: unsupported router %T", router)
+	}
+
+	cfg := &mountConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	type registration struct {
+		method, path string
+		handler      http.Handler
+	}
+
+	var (
+		registrations []registration
+		problems      []error
+	)
+	used := make(map[string]bool, len(handlers))
+	doc := v.Spec()
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Value(path)
+		for _, method := range mapkeys.Sorted(pathItem.Operations()) {
+			key := pathItem.GetOperation(method).OperationID
+			if key == "" {
+				key = method + " " + path
+			}
+
+			handler, ok := handlers[key]
+			switch {
+			case ok:
+				used[key] = true
+			case cfg.notImplemented:
+				handler = v.notImplementedHandler()
+			default:
+				problems = append(problems, fmt.Errorf("operation %q (%s %s) has no handler", key, method, path))
+				continue
+			}
+			registrations = append(registrations, registration{method, cfg.prefix + path, handler})
+		}
+	}
+
+	for _, key := range mapkeys.Sorted(handlers) {
+		if !used[key] {
+			problems = append(problems, fmt.Errorf("handler %q matches no operation", key))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("mount: %w", errors.Join(problems...))
+	}
+
+	for _, reg := range registrations {
+		if err := registrar.Register(reg.method, reg.path, reg.handler); err != nil {
+			return fmt.Errorf("mount %s %s: %w", reg.method, reg.path, err)
+		}
+	}
+	return nil
+}
+
+// notImplementedHandler answers with 501 Not Implemented through the ErrorEncoder.
+func (v *Validator) notImplementedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v.Options.ErrorEncoder(w, r, &HTTPError{Status: http.StatusNotImplemented, Err: ErrNotImplemented})
+	})
+}
+
+// serveMuxPattern translates an OpenAPI path template to the path of a ServeMux pattern
+// with wildcards named after the parameters.
+func serveMuxPattern(path string) (string, error) {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if !strings.Contains(part, "{") {
+			continue
+		}
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") || strings.Count(part, "{") != 1 {
+			return "", fmt.Errorf("path %q: ServeMux patterns only support parameters spanning a whole segment", path)
+		}
+		parts[i] = "{" + wildcardName(part[1:len(part)-1]) + "}"
+	}
+
+	pattern := strings.Join(parts, "/")
+	if strings.HasSuffix(pattern, "/") {
+		// A trailing slash only matches itself, as in OpenAPI.
+		pattern += "{$}"
+	}
+	return pattern, nil
+}
+
+// wildcardName turns a parameter name into a valid ServeMux wildcard name.
+func wildcardName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
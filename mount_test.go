@@ -0,0 +1,150 @@
+package openapi_validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+const testMountSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users/me:
+    get:
+      operationId: getMe
+      responses:
+        '200': {description: OK}
+  /users/{user-id}:
+    get:
+      operationId: getUser
+      parameters:
+        - {name: user-id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200': {description: OK}
+    delete:
+      parameters:
+        - {name: user-id, in: path, required: true, schema: {type: string}}
+      responses:
+        '204': {description: No Content}
+`
+
+func writeText(text string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(text))
+	})
+}
+
+func TestMount(t *testing.T) {
+	v, err := NewFromBytes([]byte(testMountSpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	handlers := func() map[string]http.Handler {
+		return map[string]http.Handler{
+			"getMe": writeText("me"),
+			"getUser": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("user " + r.PathValue("user_id") + mux.Vars(r)["user-id"]))
+			}),
+			"DELETE /users/{user-id}": writeText("deleted"),
+		}
+	}
+
+	routers := []struct {
+		name   string
+		router func() (any, http.Handler)
+	}{
+		{name: "ServeMux", router: func() (any, http.Handler) {
+			m := http.NewServeMux()
+			return m, m
+		}},
+		{name: "Gorilla Mux", router: func() (any, http.Handler) {
+			r := mux.NewRouter()
+			return r, r
+		}},
+	}
+
+	for _, rt := range routers {
+		t.Run(rt.name, func(t *testing.T) {
+			// Arrange
+			router, handler := rt.router()
+
+			// Act
+			err := v.Mount(router, handlers())
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for target, want := range map[string]string{
+				"GET /users/me":    "me",
+				"GET /users/42":    "user 42",
+				"DELETE /users/42": "deleted",
+			} {
+				method, path, _ := strings.Cut(target, " ")
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+				if rec.Body.String() != want {
+					t.Errorf("%s: expected %q, got %q", target, want, rec.Body.String())
+				}
+			}
+		})
+	}
+
+	t.Run("Missing And Unknown Handlers", func(t *testing.T) {
+		// Arrange
+		m := http.NewServeMux()
+
+		// Act
+		err := v.Mount(m, map[string]http.Handler{"getMe": writeText("me"), "getOrder": writeText("order")})
+
+		// Assert
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		for _, want := range []string{`"getUser"`, `"DELETE /users/{user-id}"`, `handler "getOrder"`} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("expected error to mention %s, got %v", want, err)
+			}
+		}
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest("GET", "/users/me", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected nothing to be registered, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Not Implemented", func(t *testing.T) {
+		// Arrange
+		m := http.NewServeMux()
+
+		// Act
+		err := v.Mount(m, map[string]http.Handler{"getMe": writeText("me")}, WithNotImplemented(), WithMountPrefix("/api/"))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest("GET", "/api/users/42", nil))
+		if rec.Code != http.StatusNotImplemented {
+			t.Errorf("expected 501, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Unsupported Router", func(t *testing.T) {
+		// Act
+		err := v.Mount(struct{}{}, handlers())
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -3,7 +3,9 @@ package openapi_validator
 import (
 	"net/http"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
 )
 
 // Option is a function type used to configure the Options struct.
@@ -15,24 +17,79 @@ type Options struct {
 	ValidateRequests bool
 	// ValidateResponses specifies whether outgoing responses should be validated against the spec.
 	ValidateResponses bool
+	// StrictResponses holds responses back until they have been validated, replacing invalid ones
+	// with an error response instead of sending them to the client.
+	StrictResponses bool
+	// ResponseErrorStatus is the status code used for invalid responses in strict mode, usually 500 or 502.
+	ResponseErrorStatus int
+	// MaxResponseBodyBytes caps how much of a response body is recorded for validation.
+	// Larger responses are passed through unvalidated and reported with ErrValidationSkipped. Zero means no limit.
+	MaxResponseBodyBytes int64
+	// StreamingMediaTypes lists response media types that are streamed to the client without buffering.
+	// They are not validated as a whole; text/event-stream data payloads are validated event by event.
+	StreamingMediaTypes []string
+	// ResponseErrorHandler is notified of every response that fails validation.
+	ResponseErrorHandler ResponseErrorHandler
 	// SwaggerUIPath is the URL path where Swagger UI will be served.
 	SwaggerUIPath string
 	// ErrorEncoder is used to format and send validation error responses.
 	ErrorEncoder ErrorEncoder
+	// StatusMapper derives the HTTP status code reported for a validation error.
+	StatusMapper StatusMapper
 	// Router is used for matching requests to OpenAPI paths.
 	Router routers.Router
+	// RouterFactory builds the router from the spec when Router is not set, and again on every Reload.
+	RouterFactory RouterFactory
+	// StdlibRouting builds the router with NewServeMuxRouter instead of RouterFactory,
+	// matching operations the way net/http's ServeMux routes requests.
+	StdlibRouting bool
+	// Authenticators authenticate requests for the security schemes of the spec, keyed by scheme name.
+	Authenticators map[string]Authenticator
+	// UnmatchedRoutePolicy decides what happens to requests that match no operation in the spec.
+	UnmatchedRoutePolicy UnmatchedRoutePolicy
+	// RemoteRefs lets New and NewFromFS fetch remote (http/https) external $refs over the network.
+	// Without it only local files are read.
+	RemoteRefs bool
+	// ApplyDefaults rewrites requests with the schema defaults of missing query parameters and
+	// JSON body properties, and coerces string body values to the declared scalar types, before validation.
+	ApplyDefaults bool
 }
 
+// UnmatchedRoutePolicy determines how the middleware handles requests that match no OpenAPI operation.
+type UnmatchedRoutePolicy int
+
+const (
+	// UnmatchedRoutePassThrough forwards unmatched requests to the next handler without validation.
+	UnmatchedRoutePassThrough UnmatchedRoutePolicy = iota
+	// UnmatchedRouteReject rejects requests for undocumented paths with 404 Not Found, and requests
+	// for documented paths with an undocumented method with 405 Method Not Allowed and an Allow header.
+	UnmatchedRouteReject
+	// UnmatchedRouteNotFound rejects every unmatched request with 404 Not Found,
+	// without revealing which methods the path supports.
+	UnmatchedRouteNotFound
+	// UnmatchedRouteLog logs unmatched requests and forwards them to the next handler.
+	UnmatchedRouteLog
+)
+
+// RouterFactory is a function type used to build a router for a parsed OpenAPI spec.
+type RouterFactory func(doc *openapi3.T) (routers.Router, error)
+
 // ErrorEncoder is a function type used to encode validation errors into an HTTP response.
 type ErrorEncoder func(w http.ResponseWriter, r *http.Request, err error)
 
 // DefaultOptions returns the default configuration for the validator.
 func DefaultOptions() *Options {
 	return &Options{
-		ValidateRequests:  true,
-		ValidateResponses: false,
-		SwaggerUIPath:     "/docs",
-		ErrorEncoder:      DefaultErrorEncoder,
+		ValidateRequests:     true,
+		ValidateResponses:    false,
+		ResponseErrorStatus:  http.StatusInternalServerError,
+		MaxResponseBodyBytes: 10 << 20,
+		StreamingMediaTypes:  []string{"text/event-stream", "application/x-ndjson", "application/stream+json", "multipart/x-mixed-replace"},
+		ResponseErrorHandler: DefaultResponseErrorHandler,
+		SwaggerUIPath:        "/docs",
+		ErrorEncoder:         DefaultErrorEncoder,
+		StatusMapper:         DefaultStatusMapper,
+		RouterFactory:        gorillamux.NewRouter,
 	}
 }
 
@@ -50,6 +107,45 @@ func WithValidateResponses(validate bool) Option {
 	}
 }
 
+// WithStrictResponses returns an Option that enables response validation in strict mode:
+// handler output is buffered and only sent if it matches the spec, otherwise the ErrorEncoder
+// answers with status (500 Internal Server Error if zero). Use it to enforce the contract in
+// staging and CI; buffering makes it unsuitable for streaming responses.
+func WithStrictResponses(status int) Option {
+	return func(o *Options) {
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		o.ValidateResponses = true
+		o.StrictResponses = true
+		o.ResponseErrorStatus = status
+	}
+}
+
+// WithMaxResponseBodyBytes returns an Option that sets how many bytes of a response body are
+// recorded for validation before validation is skipped. Zero disables the limit.
+func WithMaxResponseBodyBytes(n int64) Option {
+	return func(o *Options) {
+		o.MaxResponseBodyBytes = n
+	}
+}
+
+// WithStreamingMediaTypes returns an Option that sets the response media types that are streamed
+// without buffering or whole-body validation.
+func WithStreamingMediaTypes(mediaTypes ...string) Option {
+	return func(o *Options) {
+		o.StreamingMediaTypes = mediaTypes
+	}
+}
+
+// WithResponseErrorHandler returns an Option that sets the callback notified of invalid responses,
+// e.g. to route violations to structured logs, metrics or alerting.
+func WithResponseErrorHandler(handler ResponseErrorHandler) Option {
+	return func(o *Options) {
+		o.ResponseErrorHandler = handler
+	}
+}
+
 // WithSwaggerUIPath returns an Option that sets the URL path for the Swagger UI.
 func WithSwaggerUIPath(path string) Option {
 	return func(o *Options) {
@@ -64,9 +160,68 @@ func WithErrorEncoder(encoder ErrorEncoder) Option {
 	}
 }
 
+// WithStatusMapper returns an Option that sets a custom mapping from validation errors to HTTP status codes.
+func WithStatusMapper(mapper StatusMapper) Option {
+	return func(o *Options) {
+		o.StatusMapper = mapper
+	}
+}
+
 // WithRouter returns an Option that sets a custom router.
 func WithRouter(router routers.Router) Option {
 	return func(o *Options) {
 		o.Router = router
 	}
 }
+
+// WithRouterFactory returns an Option that sets the function used to build the router from the spec.
+// Unlike WithRouter, the factory is invoked again on every Reload so the router always matches the spec.
+func WithRouterFactory(factory RouterFactory) Option {
+	return func(o *Options) {
+		o.RouterFactory = factory
+	}
+}
+
+// WithRemoteRefs returns an Option that fetches remote (http/https) external $refs of specs loaded
+// with New or NewFromFS over the network, instead of failing to load them.
+func WithRemoteRefs() Option {
+	return func(o *Options) {
+		o.RemoteRefs = true
+	}
+}
+
+// WithStdlibRouting returns an Option that matches requests with net/http ServeMux patterns
+// (see NewServeMuxRouter), for services routed by http.ServeMux. New fails for specs whose
+// path templates ServeMux considers conflicting, such as "/{a}/b" and "/a/{b}".
+func WithStdlibRouting() Option {
+	return func(o *Options) {
+		o.StdlibRouting = true
+	}
+}
+
+// WithUnmatchedRoutePolicy returns an Option that sets how requests matching no OpenAPI operation are handled.
+func WithUnmatchedRoutePolicy(policy UnmatchedRoutePolicy) Option {
+	return func(o *Options) {
+		o.UnmatchedRoutePolicy = policy
+	}
+}
+
+// WithApplyDefaults returns an Option that injects schema defaults and type coercions into requests
+// before they are validated and reach the handler.
+func WithApplyDefaults() Option {
+	return func(o *Options) {
+		o.ApplyDefaults = true
+	}
+}
+
+// WithAuthenticator returns an Option that registers the Authenticator for the named security scheme.
+// Once any authenticator is registered, security requirements are enforced by the middleware and
+// the authenticated principal is available through PrincipalFromContext.
+func WithAuthenticator(scheme string, authenticator Authenticator) Option {
+	return func(o *Options) {
+		if o.Authenticators == nil {
+			o.Authenticators = make(map[string]Authenticator)
+		}
+		o.Authenticators[scheme] = authenticator
+	}
+}
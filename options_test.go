@@ -1,8 +1,12 @@
 package openapi_validator
 
 import (
+	"context"
 	"net/http"
 	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
 )
 
 func TestDefaultOptions(t *testing.T) {
@@ -25,6 +29,10 @@ func TestDefaultOptions(t *testing.T) {
 	if opts.ErrorEncoder == nil {
 		t.Error("expected ErrorEncoder to not be nil")
 	}
+
+	if opts.RouterFactory == nil {
+		t.Error("expected RouterFactory to not be nil")
+	}
 }
 
 func TestWithValidateRequests(t *testing.T) {
@@ -94,3 +102,156 @@ func TestWithRouter(t *testing.T) {
 		t.Error("expected Router to be nil")
 	}
 }
+
+func TestWithRouterFactory(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+	factory := func(doc *openapi3.T) (routers.Router, error) { return nil, nil }
+
+	// Act
+	WithRouterFactory(factory)(opts)
+
+	// Assert
+	if opts.RouterFactory == nil {
+		t.Error("expected RouterFactory to not be nil")
+	}
+}
+
+func TestWithUnmatchedRoutePolicy(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+
+	// Act
+	WithUnmatchedRoutePolicy(UnmatchedRouteReject)(opts)
+
+	// Assert
+	if opts.UnmatchedRoutePolicy != UnmatchedRouteReject {
+		t.Errorf("expected UnmatchedRoutePolicy to be UnmatchedRouteReject, got %d", opts.UnmatchedRoutePolicy)
+	}
+}
+
+func TestWithStatusMapper(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+	mapper := func(err error) int { return http.StatusTeapot }
+
+	// Act
+	WithStatusMapper(mapper)(opts)
+
+	// Assert
+	if opts.StatusMapper == nil || opts.StatusMapper(nil) != http.StatusTeapot {
+		t.Error("expected StatusMapper to be the custom mapper")
+	}
+}
+
+func TestWithAuthenticator(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+	authenticator := func(ctx context.Context, input *AuthenticationInput) (any, error) { return nil, nil }
+
+	// Act
+	WithAuthenticator("apiKey", authenticator)(opts)
+
+	// Assert
+	if opts.Authenticators["apiKey"] == nil {
+		t.Error("expected Authenticator to be registered for apiKey")
+	}
+}
+
+func TestWithStrictResponses(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+
+	// Act
+	WithStrictResponses(http.StatusBadGateway)(opts)
+
+	// Assert
+	if !opts.ValidateResponses || !opts.StrictResponses {
+		t.Error("expected ValidateResponses and StrictResponses to be true")
+	}
+
+	if opts.ResponseErrorStatus != http.StatusBadGateway {
+		t.Errorf("expected ResponseErrorStatus to be 502, got %d", opts.ResponseErrorStatus)
+	}
+}
+
+func TestWithResponseErrorHandler(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+	called := false
+	handler := func(r *http.Request, status int, err error) { called = true }
+
+	// Act
+	WithResponseErrorHandler(handler)(opts)
+	opts.ResponseErrorHandler(nil, http.StatusOK, nil)
+
+	// Assert
+	if !called {
+		t.Error("expected ResponseErrorHandler to be the custom handler")
+	}
+}
+
+func TestWithMaxResponseBodyBytes(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+
+	// Act
+	WithMaxResponseBodyBytes(1024)(opts)
+
+	// Assert
+	if opts.MaxResponseBodyBytes != 1024 {
+		t.Errorf("expected MaxResponseBodyBytes to be 1024, got %d", opts.MaxResponseBodyBytes)
+	}
+}
+
+func TestWithStreamingMediaTypes(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+
+	// Act
+	WithStreamingMediaTypes("application/x-ndjson")(opts)
+
+	// Assert
+	if len(opts.StreamingMediaTypes) != 1 || opts.StreamingMediaTypes[0] != "application/x-ndjson" {
+		t.Errorf("expected StreamingMediaTypes to be [application/x-ndjson], got %v", opts.StreamingMediaTypes)
+	}
+}
+
+func TestWithApplyDefaults(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+
+	// Act
+	WithApplyDefaults()(opts)
+
+	// Assert
+	if !opts.ApplyDefaults {
+		t.Error("expected ApplyDefaults to be true")
+	}
+}
+
+func TestWithStdlibRouting(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+
+	// Act
+	WithStdlibRouting()(opts)
+
+	// Assert
+	if !opts.StdlibRouting {
+		t.Error("expected StdlibRouting to be true")
+	}
+}
+
+func TestWithRemoteRefs(t *testing.T) {
+	// Arrange
+	opts := DefaultOptions()
+
+	// Act
+	WithRemoteRefs()(opts)
+
+	// Assert
+	if !opts.RemoteRefs {
+		t.Error("expected RemoteRefs to be true")
+	}
+}
@@ -0,0 +1,347 @@
+package openapi_validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// Params holds the parameters of the matched operation, decoded according to their
+// style and explode settings and converted to the types declared by their schemas:
+// integers as int64, numbers as float64, booleans as bool, arrays as []any and objects as map[string]any.
+type Params struct {
+	values map[string]any
+	in     map[string]string
+	// names lists the parameters the operation declares, in the order of the spec.
+	names []string
+	// decode fills values and in on first use, so handlers that never read their parameters
+	// do not pay for decoding them.
+	decode func()
+	once   sync.Once
+}
+
+// ParamsFromContext returns the decoded parameters of the request. It returns an empty Params
+// for requests that matched no operation.
+func ParamsFromContext(ctx context.Context) *Params {
+	if params, ok := ctx.Value(paramsKey).(*Params); ok {
+		return params
+	}
+	return &Params{}
+}
+
+// Value returns the decoded value of the named parameter.
+func (p *Params) Value(name string) (any, bool) {
+	if p == nil {
+		return nil, false
+	}
+	p.load()
+	value, ok := p.values[name]
+	return value, ok
+}
+
+// In returns the location (path, query, header or cookie) of the named parameter.
+func (p *Params) In(name string) string {
+	if p == nil {
+		return ""
+	}
+	p.load()
+	return p.in[name]
+}
+
+// load decodes the parameters if that has not been done yet.
+func (p *Params) load() {
+	if p.decode != nil {
+		p.once.Do(p.decode)
+	}
+}
+
+// String returns the named parameter as a string.
+func (p *Params) String(name string) (string, bool) {
+	value, ok := p.Value(name)
+	if !ok {
+		return "", false
+	}
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []any, map[string]any:
+		return "", false
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// Int returns the named parameter as an int.
+func (p *Params) Int(name string) (int, bool) {
+	value, ok := p.Value(name)
+	if !ok {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case int64:
+		return int(v), true
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false
+		}
+		return int(v), true
+	case string:
+		i, err := strconv.Atoi(v)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// Float returns the named parameter as a float64.
+func (p *Params) Float(name string) (float64, bool) {
+	value, ok := p.Value(name)
+	if !ok {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// Bool returns the named parameter as a bool.
+func (p *Params) Bool(name string) (bool, bool) {
+	value, ok := p.Value(name)
+	if !ok {
+		return false, false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
+
+// StringSlice returns the named array parameter with its items formatted as strings.
+func (p *Params) StringSlice(name string) ([]string, bool) {
+	value, ok := p.Value(name)
+	if !ok {
+		return nil, false
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		out = append(out, fmt.Sprint(item))
+	}
+	return out, true
+}
+
+// Object returns the named object parameter, such as a deepObject query parameter.
+func (p *Params) Object(name string) (map[string]any, bool) {
+	value, ok := p.Value(name)
+	if !ok {
+		return nil, false
+	}
+	object, ok := value.(map[string]any)
+	return object, ok
+}
+
+// Bind copies the decoded parameters of the request into the struct pointed to by dst.
+// Fields are matched by their `param` tag, or by field name against the parameter names of
+// the spec: exactly if possible, otherwise case-insensitively, failing when that is ambiguous.
+// Values are converted with encoding/json, so nested structs can be used for object parameters.
+// Fields without a matching parameter are left untouched.
+func Bind(ctx context.Context, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("bind: destination must be a non-nil pointer to a struct")
+	}
+
+	params := ParamsFromContext(ctx)
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Tag.Get("param")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			var err error
+			if name, err = params.lookupName(field.Name); err != nil {
+				return err
+			}
+		}
+
+		value, ok := params.Value(name)
+		if !ok {
+			continue
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("bind %q: %w", name, err)
+		}
+		if err := json.Unmarshal(data, rv.Field(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("bind %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// lookupName returns the declared parameter name matching fieldName, preferring an exact match
+// over a case-insensitive one. It fails when several parameters match case-insensitively only.
+func (p *Params) lookupName(fieldName string) (string, error) {
+	var matches []string
+	for _, name := range p.names {
+		if name == fieldName {
+			return name, nil
+		}
+		if strings.EqualFold(name, fieldName) {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", nil
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("bind %s: ambiguous parameters %s, use a param tag", fieldName, strings.Join(matches, ", "))
+}
+
+// decodeParams returns the parameters of route, decoding those present in r when they are
+// first read. Parameters that cannot be decoded are left out; request validation reports them.
+func decodeParams(r *http.Request, route *routers.Route, pathParams map[string]string) *Params {
+	params := &Params{}
+	declared := operationParameters(route)
+	for _, ref := range declared {
+		params.names = append(params.names, ref.Value.Name)
+	}
+
+	params.decode = func() {
+		params.values = make(map[string]any)
+		params.in = make(map[string]string)
+		input := &openapi3filter.RequestValidationInput{Request: r, PathParams: pathParams, Route: route}
+		for _, ref := range declared {
+			param := ref.Value
+			value, found, err := decodeParam(input, param)
+			if err != nil || !found {
+				continue
+			}
+			params.values[param.Name] = value
+			params.in[param.Name] = param.In
+		}
+	}
+	return params
+}
+
+// decodeParam decodes a single parameter with openapi3filter, the decoder request validation
+// uses, so handlers get the values that were validated. openapi3filter does not expose its
+// decoder, so the parameter is validated against a schema that decodes like its own but fails
+// for every value: oneOf the schema twice. The resulting schema error carries the decoded
+// value; TestDecodeParam_SchemaErrorValue pins that behavior of openapi3filter.
+func decodeParam(input *openapi3filter.RequestValidationInput, param *openapi3.Parameter) (any, bool, error) {
+	probe := *param
+	switch {
+	case param.Content != nil:
+		probe.Content = make(openapi3.Content, len(param.Content))
+		for mediaType, media := range param.Content {
+			if media == nil || media.Schema == nil {
+				return nil, false, nil
+			}
+			copied := *media
+			copied.Schema = rejectingSchema(media.Schema)
+			probe.Content[mediaType] = &copied
+		}
+	case param.Schema != nil:
+		probe.Schema = rejectingSchema(param.Schema)
+	default:
+		return nil, false, nil
+	}
+
+	err := openapi3filter.ValidateParameter(input.Request.Context(), input, &probe)
+	var schemaErr *openapi3.SchemaError
+	switch {
+	case err == nil || errors.Is(err, openapi3filter.ErrInvalidRequired):
+		return paramDefault(param)
+	case errors.As(err, &schemaErr) && schemaErr.SchemaField == "oneOf":
+		return normalizeParamValue(schemaErr.Value), true, nil
+	default:
+		return nil, false, err
+	}
+}
+
+// rejectingSchema returns a schema that decodes like schema but matches no value.
+func rejectingSchema(schema *openapi3.SchemaRef) *openapi3.SchemaRef {
+	return openapi3.NewSchemaRef("", &openapi3.Schema{OneOf: openapi3.SchemaRefs{schema, schema}})
+}
+
+// normalizeParamValue converts the int32 values of int32-formatted integers to int64, so that
+// every integer parameter is exposed with the same type.
+func normalizeParamValue(value any) any {
+	switch v := value.(type) {
+	case int32:
+		return int64(v)
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeParamValue(item)
+		}
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeParamValue(item)
+		}
+	}
+	return value
+}
+
+// paramDefault returns the schema default of a parameter missing from the request,
+// with integer defaults converted to int64 like decoded values.
+func paramDefault(param *openapi3.Parameter) (any, bool, error) {
+	if param.Schema == nil || param.Schema.Value == nil || param.Schema.Value.Default == nil {
+		return nil, false, nil
+	}
+	schema := param.Schema.Value
+	def := schema.Default
+	if f, ok := def.(float64); ok && schemaIs(schema, openapi3.TypeInteger) {
+		return int64(f), true, nil
+	}
+	if items, ok := def.([]any); ok && schema.Items != nil && schemaIs(schema.Items.Value, openapi3.TypeInteger) {
+		converted := make([]any, len(items))
+		for i, item := range items {
+			if f, ok := item.(float64); ok {
+				converted[i] = int64(f)
+			} else {
+				converted[i] = item
+			}
+		}
+		return converted, true, nil
+	}
+	return def, true, nil
+}
+
+// schemaIs reports whether schema declares exactly the given type.
+func schemaIs(schema *openapi3.Schema, typ string) bool {
+	return schema != nil && schema.Type.Is(typ)
+}
@@ -0,0 +1,441 @@
+package openapi_validator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+const testParamsSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /items/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: integer}}
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, default: 10}}
+        - {name: active, in: query, schema: {type: boolean}}
+        - {name: tags, in: query, explode: false, schema: {type: array, items: {type: string}}}
+        - {name: ids, in: query, schema: {type: array, items: {type: integer}}}
+        - name: filter
+          in: query
+          style: deepObject
+          explode: true
+          schema:
+            type: object
+            properties:
+              min: {type: number}
+              owner: {type: string}
+        - {name: X-Trace, in: header, schema: {type: string}}
+        - {name: session, in: cookie, schema: {type: string}}
+      responses:
+        '200':
+          description: OK
+`
+
+func TestParamsFromContext(t *testing.T) {
+	v, err := NewFromBytes([]byte(testParamsSpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	var params *Params
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params = ParamsFromContext(r.Context())
+	}))
+
+	t.Run("Typed Getters", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/items/7?active=true&tags=a,b&ids=1&ids=2&filter[min]=1.5&filter[owner]=bob", nil)
+		req.Header.Set("X-Trace", "abc")
+		req.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if id, ok := params.Int("id"); !ok || id != 7 {
+			t.Errorf("expected id 7, got %v (%v)", id, ok)
+		}
+		if params.In("id") != "path" {
+			t.Errorf("expected id in path, got %q", params.In("id"))
+		}
+		if limit, ok := params.Int("limit"); !ok || limit != 10 {
+			t.Errorf("expected default limit 10, got %v (%v)", limit, ok)
+		}
+		if active, ok := params.Bool("active"); !ok || !active {
+			t.Errorf("expected active true, got %v (%v)", active, ok)
+		}
+		if tags, ok := params.StringSlice("tags"); !ok || !reflect.DeepEqual(tags, []string{"a", "b"}) {
+			t.Errorf("expected tags [a b], got %v (%v)", tags, ok)
+		}
+		if ids, ok := params.Value("ids"); !ok || !reflect.DeepEqual(ids, []any{int64(1), int64(2)}) {
+			t.Errorf("expected ids [1 2], got %#v (%v)", ids, ok)
+		}
+		want := map[string]any{"min": 1.5, "owner": "bob"}
+		if filter, ok := params.Object("filter"); !ok || !reflect.DeepEqual(filter, want) {
+			t.Errorf("expected filter %v, got %v (%v)", want, filter, ok)
+		}
+		if trace, ok := params.String("X-Trace"); !ok || trace != "abc" {
+			t.Errorf("expected X-Trace abc, got %q (%v)", trace, ok)
+		}
+		if session, ok := params.String("session"); !ok || session != "s1" {
+			t.Errorf("expected session s1, got %q (%v)", session, ok)
+		}
+	})
+
+	t.Run("Missing Parameter", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/items/7", nil)
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if _, ok := params.Bool("active"); ok {
+			t.Error("expected active to be absent")
+		}
+		if _, ok := params.Object("filter"); ok {
+			t.Error("expected filter to be absent")
+		}
+	})
+
+	t.Run("Wrong Type", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/items/7?tags=a", nil)
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if _, ok := params.Int("tags"); ok {
+			t.Error("expected Int on an array parameter to fail")
+		}
+	})
+
+	t.Run("No Operation", func(t *testing.T) {
+		// Act
+		p := ParamsFromContext(context.Background())
+
+		// Assert
+		if _, ok := p.Value("id"); ok {
+			t.Error("expected no parameters without a matched operation")
+		}
+	})
+}
+
+func TestDecodeParamStyles(t *testing.T) {
+	tests := []struct {
+		name  string
+		param string
+		path  string
+		want  any
+	}{
+		{
+			name:  "Label Array",
+			param: `{name: v, in: path, required: true, style: label, schema: {type: array, items: {type: integer}}}`,
+			path:  "/x/.1,2",
+			want:  []any{int64(1), int64(2)},
+		},
+		{
+			name:  "Matrix Exploded Array",
+			param: `{name: v, in: path, required: true, style: matrix, explode: true, schema: {type: array, items: {type: string}}}`,
+			path:  "/x/;v=a;v=b",
+			want:  []any{"a", "b"},
+		},
+		{
+			name:  "Simple Object",
+			param: `{name: v, in: path, required: true, schema: {type: object, properties: {n: {type: integer}, s: {type: string}}}}`,
+			path:  "/x/n,3,s,y",
+			want:  map[string]any{"n": int64(3), "s": "y"},
+		},
+		{
+			name:  "Simple Exploded Object",
+			param: `{name: v, in: path, required: true, explode: true, schema: {type: object, properties: {n: {type: integer}}}}`,
+			path:  "/x/n=3",
+			want:  map[string]any{"n": int64(3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			v, err := NewFromBytes([]byte(`
+openapi: 3.0.0
+info: {title: Test API, version: 1.0.0}
+paths:
+  /x/{v}:
+    get:
+      parameters:
+        - ` + tt.param + `
+      responses:
+        '200': {description: OK}
+`))
+			if err != nil {
+				t.Fatalf("failed to create validator: %v", err)
+			}
+
+			var got any
+			handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = ParamsFromContext(r.Context()).Value("v")
+			}))
+
+			// Act
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", tt.path, nil))
+
+			// Assert
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBind(t *testing.T) {
+	v, err := NewFromBytes([]byte(testParamsSpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	type filter struct {
+		Min   float64 `json:"min"`
+		Owner string  `json:"owner"`
+	}
+	type query struct {
+		ID     int64
+		Limit  int
+		Active bool
+		Tags   []string `param:"tags"`
+		Filter *filter
+		Trace  string `param:"X-Trace"`
+		Ignore string `param:"-"`
+	}
+
+	t.Run("Binds Parameters", func(t *testing.T) {
+		// Arrange
+		var (
+			q       query
+			bindErr error
+		)
+		handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bindErr = Bind(r.Context(), &q)
+		}))
+		req := httptest.NewRequest("GET", "/items/3?active=true&tags=x,y&filter[min]=2&filter[owner]=amy", nil)
+		req.Header.Set("X-Trace", "t1")
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		if bindErr != nil {
+			t.Fatalf("unexpected error: %v", bindErr)
+		}
+		want := query{ID: 3, Limit: 10, Active: true, Tags: []string{"x", "y"}, Filter: &filter{Min: 2, Owner: "amy"}, Trace: "t1"}
+		if !reflect.DeepEqual(q, want) {
+			t.Errorf("expected %+v, got %+v", want, q)
+		}
+	})
+
+	t.Run("Invalid Destination", func(t *testing.T) {
+		// Act
+		err := Bind(context.Background(), query{})
+
+		// Assert
+		if err == nil {
+			t.Error("expected an error for a non-pointer destination")
+		}
+	})
+
+	t.Run("Incompatible Field", func(t *testing.T) {
+		// Arrange
+		var (
+			q       struct{ Tags int }
+			bindErr error
+		)
+		handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bindErr = Bind(r.Context(), &q)
+		}))
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/items/3?tags=x", nil))
+
+		// Assert
+		if bindErr == nil {
+			t.Error("expected an error binding an array to an int")
+		}
+	})
+}
+
+func TestBind_FieldNames(t *testing.T) {
+	v, err := NewFromBytes([]byte(`
+openapi: 3.0.0
+info: {title: Test API, version: 1.0.0}
+paths:
+  /items:
+    get:
+      parameters:
+        - {name: page, in: query, schema: {type: integer, format: int32}}
+        - {name: Page, in: header, schema: {type: integer}}
+        - {name: sort, in: query, schema: {type: string}}
+        - {name: SORT, in: header, schema: {type: string}}
+      responses:
+        '200': {description: OK}
+`))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	bind := func(dst any) error {
+		var bindErr error
+		handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bindErr = Bind(r.Context(), dst)
+		}))
+		req := httptest.NewRequest("GET", "/items?page=2&sort=name", nil)
+		req.Header.Set("Page", "3")
+		req.Header.Set("SORT", "date")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		return bindErr
+	}
+
+	t.Run("Exact Match Wins", func(t *testing.T) {
+		// Arrange
+		var q struct {
+			Page int64
+		}
+
+		// Act
+		err := bind(&q)
+
+		// Assert
+		if err != nil || q.Page != 3 {
+			t.Errorf("expected the Page header, got %d (%v)", q.Page, err)
+		}
+	})
+
+	t.Run("Ambiguous Name", func(t *testing.T) {
+		// Arrange
+		var q struct {
+			Sort string
+		}
+
+		// Act
+		err := bind(&q)
+
+		// Assert
+		if err == nil {
+			t.Errorf("expected an error for the ambiguous field, got %q", q.Sort)
+		}
+	})
+
+	t.Run("Int32 Values", func(t *testing.T) {
+		// Arrange
+		var got any
+		handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ = ParamsFromContext(r.Context()).Value("page")
+		}))
+
+		// Act
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/items?page=2", nil))
+
+		// Assert
+		if got != int64(2) {
+			t.Errorf("expected int64(2), got %#v", got)
+		}
+	})
+}
+
+// TestDecodeParam_SchemaErrorValue pins the openapi3filter behavior decodeParam relies on: a value
+// failing a oneOf schema is reported as a *openapi3.SchemaError carrying the decoded value.
+func TestDecodeParam_SchemaErrorValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		param string
+		query string
+		want  any
+	}{
+		{
+			name:  "Form Array",
+			param: `{name: v, in: query, explode: false, schema: {type: array, items: {type: integer}}}`,
+			query: "v=1,2",
+			want:  []any{int64(1), int64(2)},
+		},
+		{
+			name:  "Deep Object",
+			param: `{name: v, in: query, style: deepObject, explode: true, schema: {type: object, properties: {n: {type: number}}}}`,
+			query: "v[n]=1.5",
+			want:  map[string]any{"n": 1.5},
+		},
+		{
+			name:  "JSON Content",
+			param: `{name: v, in: query, content: {application/json: {schema: {type: object}}}}`,
+			query: `v={"a":true}`,
+			want:  map[string]any{"a": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info: {title: Test API, version: 1.0.0}
+paths:
+  /x:
+    get:
+      parameters:
+        - ` + tt.param + `
+      responses:
+        '200': {description: OK}
+`))
+			if err != nil {
+				t.Fatalf("failed to load spec: %v", err)
+			}
+			param := doc.Paths.Find("/x").Get.Parameters[0].Value
+			req := httptest.NewRequest("GET", "/x?"+url.PathEscape(tt.query), nil)
+			input := &openapi3filter.RequestValidationInput{Request: req}
+
+			// Act
+			value, found, err := decodeParam(input, param)
+
+			// Assert
+			if err != nil || !found {
+				t.Fatalf("openapi3filter no longer reports decoded values in SchemaError.Value: %v", err)
+			}
+			if !reflect.DeepEqual(value, tt.want) {
+				t.Errorf("expected %#v, got %#v", tt.want, value)
+			}
+		})
+	}
+}
+
+func TestDecodeParams_Lazy(t *testing.T) {
+	// Arrange
+	v, err := NewFromBytes([]byte(testParamsSpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	route, pathParams, err := v.FindRoute(httptest.NewRequest("GET", "/items/1?limit=5", nil))
+	if err != nil {
+		t.Fatalf("failed to find route: %v", err)
+	}
+
+	// Act
+	params := decodeParams(httptest.NewRequest("GET", "/items/1?limit=5", nil), route, pathParams)
+
+	// Assert
+	if params.values != nil {
+		t.Fatal("expected the parameters not to be decoded before they are read")
+	}
+	if limit, ok := params.Int("limit"); !ok || limit != 5 {
+		t.Errorf("expected limit 5, got %d", limit)
+	}
+}
@@ -0,0 +1,84 @@
+package openapi_validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ProblemDetails is an RFC 9457 (formerly RFC 7807) problem details object.
+// Errors is an extension member carrying the structured validation failures.
+type ProblemDetails struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// ProblemDetailsOption is a function type used to configure a problem details error encoder.
+type ProblemDetailsOption func(*problemDetailsConfig)
+
+type problemDetailsConfig struct {
+	typeBase string
+}
+
+// WithProblemTypeBase returns a ProblemDetailsOption that sets the base URI of the problem type.
+// The type is built by appending a slug of the status text, e.g. "https://example.com/problems/bad-request".
+// Without a base, the type is "about:blank".
+func WithProblemTypeBase(base string) ProblemDetailsOption {
+	return func(c *problemDetailsConfig) {
+		c.typeBase = base
+	}
+}
+
+// ProblemDetailsErrorEncoder is a built-in implementation of ErrorEncoder that sends an
+// application/problem+json response with the "about:blank" problem type.
+// Use NewProblemDetailsErrorEncoder to customize it.
+var ProblemDetailsErrorEncoder = NewProblemDetailsErrorEncoder()
+
+// NewProblemDetailsErrorEncoder returns an ErrorEncoder that sends RFC 9457 problem details.
+func NewProblemDetailsErrorEncoder(opts ...ProblemDetailsOption) ErrorEncoder {
+	config := &problemDetailsConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		status := StatusCode(err)
+		details := FieldErrors(err)
+
+		messages := make([]string, 0, len(details))
+		for _, d := range details {
+			messages = append(messages, d.Message)
+		}
+
+		resp := ProblemDetails{
+			Type:     config.problemType(status),
+			Title:    http.StatusText(status),
+			Status:   status,
+			Detail:   strings.Join(messages, "; "),
+			Instance: r.URL.Path,
+			Errors:   details,
+		}
+
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// problemType returns the problem type URI for status.
+func (c *problemDetailsConfig) problemType(status int) string {
+	if c.typeBase == "" {
+		return "about:blank"
+	}
+
+	base := c.typeBase
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	slug := strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "-"))
+	return base + slug
+}
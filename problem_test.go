@@ -0,0 +1,66 @@
+package openapi_validator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProblemDetailsErrorEncoder(t *testing.T) {
+	t.Run("Default Type", func(t *testing.T) {
+		// Arrange
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/users?limit=1", nil)
+
+		// Act
+		ProblemDetailsErrorEncoder(w, r, errors.New("test validation error"))
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", w.Code)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+			t.Errorf("expected Content-Type application/problem+json, got %s", ct)
+		}
+
+		var resp ProblemDetails
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if resp.Type != "about:blank" || resp.Title != "Bad Request" || resp.Status != http.StatusBadRequest {
+			t.Errorf("unexpected problem details %+v", resp)
+		}
+
+		if resp.Instance != "/users" {
+			t.Errorf("expected instance /users, got %s", resp.Instance)
+		}
+
+		if resp.Detail != "test validation error" || len(resp.Errors) != 1 {
+			t.Errorf("expected detail and errors to describe the failure, got %+v", resp)
+		}
+	})
+
+	t.Run("Custom Type Base", func(t *testing.T) {
+		// Arrange
+		encoder := NewProblemDetailsErrorEncoder(WithProblemTypeBase("https://example.com/problems"))
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+
+		// Act
+		encoder(w, r, errors.New("test validation error"))
+
+		// Assert
+		var resp ProblemDetails
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if resp.Type != "https://example.com/problems/bad-request" {
+			t.Errorf("expected type https://example.com/problems/bad-request, got %s", resp.Type)
+		}
+	})
+}
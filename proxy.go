@@ -0,0 +1,200 @@
+package openapi_validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+// ProxyMode determines what a Proxy does with requests and responses that fail validation.
+type ProxyMode int
+
+const (
+	// ProxyEnforce rejects invalid requests before they reach the upstream, and replaces invalid
+	// upstream responses with a 502 Bad Gateway error response.
+	ProxyEnforce ProxyMode = iota
+	// ProxyReportOnly forwards invalid requests and responses unchanged and only reports them.
+	ProxyReportOnly
+)
+
+// RequestErrorHandler is a function type called with requests that failed validation
+// but were forwarded anyway in ProxyReportOnly mode.
+type RequestErrorHandler func(r *http.Request, err error)
+
+// DefaultRequestErrorHandler is the built-in RequestErrorHandler. It logs the violation
+// with log/slog at warning level.
+func DefaultRequestErrorHandler(r *http.Request, err error) {
+	slog.WarnContext(r.Context(), "request validation failed",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"error", err,
+	)
+}
+
+// ProxyOption is a function type used to configure a Proxy.
+type ProxyOption func(*Proxy)
+
+// WithProxyMode returns a ProxyOption that sets whether violations are enforced or only reported.
+func WithProxyMode(mode ProxyMode) ProxyOption {
+	return func(p *Proxy) {
+		p.mode = mode
+	}
+}
+
+// WithRequestErrorHandler returns a ProxyOption that sets the handler notified of invalid
+// requests forwarded in ProxyReportOnly mode.
+func WithRequestErrorHandler(handler RequestErrorHandler) ProxyOption {
+	return func(p *Proxy) {
+		p.requestErrorHandler = handler
+	}
+}
+
+// Proxy is a validating reverse proxy, for use as a contract-enforcing sidecar in front of
+// services that cannot validate themselves. Requests are routed and validated like in
+// Middleware before being forwarded; when ValidateResponses is enabled, upstream responses are
+// validated as they come back. Response violations are reported to the validator's ResponseErrorHandler.
+type Proxy struct {
+	// ReverseProxy forwards requests to the target. Its Transport, ErrorLog and FlushInterval
+	// may be customized; Rewrite, ModifyResponse and ErrorHandler are used by the Proxy.
+	ReverseProxy *httputil.ReverseProxy
+
+	v                   *Validator
+	mode                ProxyMode
+	requestErrorHandler RequestErrorHandler
+}
+
+// NewProxy returns a Proxy forwarding requests to target, validating them against the spec.
+func (v *Validator) NewProxy(target *url.URL, opts ...ProxyOption) *Proxy {
+	p := &Proxy{
+		v:                   v,
+		mode:                ProxyEnforce,
+		requestErrorHandler: DefaultRequestErrorHandler,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.ReverseProxy = &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.SetXForwarded()
+		},
+		ModifyResponse: p.validateResponse,
+		ErrorHandler:   p.handleError,
+	}
+	return p
+}
+
+// ServeHTTP validates r and forwards it to the target. Requests below SwaggerUIPath are
+// forwarded unvalidated, as Middleware passes them through.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v := p.v
+	if v.isSwaggerUIRequest(r) {
+		p.ReverseProxy.ServeHTTP(w, r)
+		return
+	}
+
+	route, pathParams, err := v.router().FindRoute(r)
+	if err != nil {
+		if v.handleUnmatchedRoute(w, r, err) {
+			p.ReverseProxy.ServeHTTP(w, r)
+		}
+		return
+	}
+
+	validated, err := v.ValidateRequest(r, route, pathParams)
+	if err != nil {
+		if p.mode == ProxyEnforce {
+			v.Options.ErrorEncoder(w, validated, err)
+			return
+		}
+		if p.requestErrorHandler != nil {
+			p.requestErrorHandler(validated, err)
+		}
+	}
+	p.ReverseProxy.ServeHTTP(w, validated)
+}
+
+// validateResponse validates an upstream response, restoring its body for the client.
+func (p *Proxy) validateResponse(resp *http.Response) error {
+	v := p.v
+	req := resp.Request
+	op, ok := OperationFromContext(req.Context())
+	if !v.Options.ValidateResponses || !ok || resp.StatusCode == http.StatusSwitchingProtocols {
+		return nil
+	}
+
+	report := func(err error) {
+		if v.Options.ResponseErrorHandler != nil {
+			v.Options.ResponseErrorHandler(req, resp.StatusCode, err)
+		}
+	}
+
+	// Streams are forwarded as they arrive.
+	if streamingMediaType(resp.Header, v.Options.StreamingMediaTypes) != "" {
+		return nil
+	}
+
+	body, complete, err := readUpTo(resp.Body, v.Options.MaxResponseBodyBytes)
+	if err != nil {
+		return err
+	}
+	if !complete {
+		// Pass the rest of the body through without holding it in memory.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		report(fmt.Errorf("%w: response body exceeds %d bytes", ErrValidationSkipped, v.Options.MaxResponseBodyBytes))
+		return nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = v.ValidateResponse(req, op.Route, PathParamsFromContext(req.Context()), resp.StatusCode, resp.Header, body)
+	if err == nil {
+		return nil
+	}
+	report(err)
+	if p.mode == ProxyEnforce {
+		return &HTTPError{Status: http.StatusBadGateway, Err: err}
+	}
+	return nil
+}
+
+// handleError answers invalid upstream responses through the ErrorEncoder and
+// unreachable upstreams with 502 Bad Gateway.
+func (p *Proxy) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		p.v.Options.ErrorEncoder(w, r, httpErr)
+		return
+	}
+	slog.ErrorContext(r.Context(), "proxy upstream error",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"error", err,
+	)
+	w.WriteHeader(http.StatusBadGateway)
+}
+
+// readUpTo reads r up to limit bytes (no limit when zero), reporting whether it reached the end.
+func readUpTo(r io.Reader, limit int64) ([]byte, bool, error) {
+	if limit <= 0 {
+		data, err := io.ReadAll(r)
+		return data, err == nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(data)) > limit {
+		return data, false, nil
+	}
+	return data, true, nil
+}
@@ -0,0 +1,264 @@
+package openapi_validator
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const testProxySpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /items/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id: {type: integer}
+`
+
+// newTestUpstream answers /items/1 with a valid body and any other item with an invalid one.
+func newTestUpstream(t *testing.T, hits *atomic.Int32) *url.URL {
+	t.Helper()
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/items/1" {
+			w.Write([]byte(`{"id":1}`))
+			return
+		}
+		w.Write([]byte(`{"id":"oops"}`))
+	}))
+	t.Cleanup(upstream.Close)
+
+	target, err := url.Parse(upstream.URL)
+	if err != nil {
+		t.Fatalf("failed to parse upstream URL: %v", err)
+	}
+	return target
+}
+
+func TestProxy(t *testing.T) {
+	var reported []error
+	v, err := NewFromBytes([]byte(testProxySpec), WithValidateResponses(true), WithResponseErrorHandler(func(r *http.Request, status int, err error) {
+		reported = append(reported, err)
+	}))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	var hits atomic.Int32
+	target := newTestUpstream(t, &hits)
+
+	t.Run("Enforce", func(t *testing.T) {
+		proxy := v.NewProxy(target)
+
+		t.Run("Valid Exchange", func(t *testing.T) {
+			// Arrange
+			reported = nil
+			hits.Store(0)
+			rec := httptest.NewRecorder()
+
+			// Act
+			proxy.ServeHTTP(rec, httptest.NewRequest("GET", "/items/1", nil))
+
+			// Assert
+			if rec.Code != http.StatusOK || rec.Body.String() != `{"id":1}` {
+				t.Errorf("expected the upstream response, got %d %q", rec.Code, rec.Body.String())
+			}
+			if len(reported) != 0 {
+				t.Errorf("expected no violations, got %v", reported)
+			}
+		})
+
+		t.Run("Invalid Request", func(t *testing.T) {
+			// Arrange
+			hits.Store(0)
+			rec := httptest.NewRecorder()
+
+			// Act
+			proxy.ServeHTTP(rec, httptest.NewRequest("GET", "/items/abc", nil))
+
+			// Assert
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rec.Code)
+			}
+			if hits.Load() != 0 {
+				t.Error("expected the upstream not to be called")
+			}
+		})
+
+		t.Run("Invalid Response", func(t *testing.T) {
+			// Arrange
+			reported = nil
+			rec := httptest.NewRecorder()
+
+			// Act
+			proxy.ServeHTTP(rec, httptest.NewRequest("GET", "/items/2", nil))
+
+			// Assert
+			if rec.Code != http.StatusBadGateway {
+				t.Errorf("expected 502, got %d", rec.Code)
+			}
+			if strings.HasPrefix(rec.Body.String(), `{"id":"oops"}`) {
+				t.Error("expected the invalid response to be withheld")
+			}
+			if len(reported) != 1 {
+				t.Errorf("expected one violation, got %v", reported)
+			}
+		})
+	})
+
+	t.Run("Report Only", func(t *testing.T) {
+		var requestErrors []error
+		proxy := v.NewProxy(target,
+			WithProxyMode(ProxyReportOnly),
+			WithRequestErrorHandler(func(r *http.Request, err error) {
+				requestErrors = append(requestErrors, err)
+			}),
+		)
+
+		t.Run("Invalid Request Is Forwarded", func(t *testing.T) {
+			// Arrange
+			hits.Store(0)
+			rec := httptest.NewRecorder()
+
+			// Act
+			proxy.ServeHTTP(rec, httptest.NewRequest("GET", "/items/abc", nil))
+
+			// Assert
+			if hits.Load() != 1 {
+				t.Error("expected the upstream to be called")
+			}
+			if len(requestErrors) != 1 {
+				t.Errorf("expected one request violation, got %v", requestErrors)
+			}
+		})
+
+		t.Run("Invalid Response Is Forwarded", func(t *testing.T) {
+			// Arrange
+			reported = nil
+			rec := httptest.NewRecorder()
+
+			// Act
+			proxy.ServeHTTP(rec, httptest.NewRequest("GET", "/items/2", nil))
+
+			// Assert
+			if rec.Code != http.StatusOK || rec.Body.String() != `{"id":"oops"}` {
+				t.Errorf("expected the upstream response, got %d %q", rec.Code, rec.Body.String())
+			}
+			if len(reported) != 1 {
+				t.Errorf("expected one violation, got %v", reported)
+			}
+		})
+	})
+}
+
+func TestProxyLargeResponse(t *testing.T) {
+	// Arrange
+	var reported error
+	v, err := NewFromBytes([]byte(testProxySpec),
+		WithValidateResponses(true),
+		WithMaxResponseBodyBytes(4),
+		WithResponseErrorHandler(func(r *http.Request, status int, err error) {
+			reported = err
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	var hits atomic.Int32
+	proxy := v.NewProxy(newTestUpstream(t, &hits))
+	rec := httptest.NewRecorder()
+
+	// Act
+	proxy.ServeHTTP(rec, httptest.NewRequest("GET", "/items/2", nil))
+
+	// Assert
+	body, _ := io.ReadAll(rec.Body)
+	if string(body) != `{"id":"oops"}` {
+		t.Errorf("expected the full body to be forwarded, got %q", body)
+	}
+	if !errors.Is(reported, ErrValidationSkipped) {
+		t.Errorf("expected ErrValidationSkipped, got %v", reported)
+	}
+}
+
+func TestProxyResponseValidationDisabled(t *testing.T) {
+	// Arrange
+	var reported []error
+	v, err := NewFromBytes([]byte(testProxySpec), WithResponseErrorHandler(func(r *http.Request, status int, err error) {
+		reported = append(reported, err)
+	}))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	var hits atomic.Int32
+	proxy := v.NewProxy(newTestUpstream(t, &hits))
+	rec := httptest.NewRecorder()
+
+	// Act
+	proxy.ServeHTTP(rec, httptest.NewRequest("GET", "/items/2", nil))
+
+	// Assert
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), `{"id":"oops"}`) {
+		t.Errorf("expected the upstream response to be forwarded, got %d %s", rec.Code, rec.Body.String())
+	}
+	if len(reported) != 0 {
+		t.Errorf("expected no violations, got %v", reported)
+	}
+}
+
+func TestProxySwaggerUIPath(t *testing.T) {
+	// Arrange
+	v, err := NewFromBytes([]byte(testProxySpec), WithUnmatchedRoutePolicy(UnmatchedRouteReject))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	var hits atomic.Int32
+	proxy := v.NewProxy(newTestUpstream(t, &hits))
+	rec := httptest.NewRecorder()
+
+	// Act
+	proxy.ServeHTTP(rec, httptest.NewRequest("GET", "/docs/openapi.json", nil))
+
+	// Assert
+	if rec.Code != http.StatusOK || hits.Load() != 1 {
+		t.Errorf("expected the request to be forwarded unvalidated, got %d", rec.Code)
+	}
+}
+
+func TestProxyUnreachableUpstream(t *testing.T) {
+	// Arrange
+	v, err := NewFromBytes([]byte(testProxySpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	proxy := v.NewProxy(&url.URL{Scheme: "http", Host: "127.0.0.1:1"})
+	proxy.ReverseProxy.ErrorLog = nil
+	rec := httptest.NewRecorder()
+
+	// Act
+	proxy.ServeHTTP(rec, httptest.NewRequest("GET", "/items/1", nil))
+
+	// Assert
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("expected 502, got %d", rec.Code)
+	}
+}
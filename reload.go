@@ -0,0 +1,138 @@
+package openapi_validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// specSource knows how to (re)load a spec and, optionally, how to detect that it changed.
+type specSource struct {
+	load func(options *Options) (*openapi3.T, error)
+	stat func() (fs.FileInfo, error)
+}
+
+// specState is the spec and router pair currently in use. It is swapped atomically on Reload.
+// A nil router means the router configured in Options is used.
+type specState struct {
+	swagger *openapi3.T
+	router  routers.Router
+}
+
+// Spec returns the OpenAPI document currently in use, reflecting the latest successful Reload.
+func (v *Validator) Spec() *openapi3.T {
+	if st := v.state.Load(); st != nil {
+		return st.swagger
+	}
+	return v.Swagger
+}
+
+// router returns the router matching the current spec.
+func (v *Validator) router() routers.Router {
+	if st := v.state.Load(); st != nil && st.router != nil {
+		return st.router
+	}
+	return v.Options.Router
+}
+
+// newRouter builds a router for swagger using the configured RouterFactory, defaulting to gorillamux.
+func (v *Validator) newRouter(swagger *openapi3.T) (routers.Router, error) {
+	factory := v.Options.RouterFactory
+	switch {
+	case v.Options.StdlibRouting:
+		factory = NewServeMuxRouter
+	case factory == nil:
+		factory = gorillamux.NewRouter
+	}
+	return factory(swagger)
+}
+
+// Reload re-parses the spec from its original source, validates it and builds a new router.
+// The new spec and router are swapped in atomically, so in-flight requests finish with the
+// previous version. If loading or validation fails, the previous version is kept and the error returned.
+//
+// Validators created with NewFromBytes or NewFromReader simply re-parse the same document.
+// Reload fails when a router was supplied through WithRouter, since such a router is bound to
+// the original spec; use WithRouterFactory instead.
+func (v *Validator) Reload() error {
+	v.reloadMu.Lock()
+	defer v.reloadMu.Unlock()
+
+	if v.source.load == nil {
+		return errors.New("reload: validator has no spec source")
+	}
+
+	if st := v.state.Load(); (st == nil || st.router == nil) && v.customRouter {
+		return errors.New("reload: router supplied via WithRouter cannot be rebuilt, use WithRouterFactory")
+	}
+
+	swagger, err := v.source.load(v.Options)
+	if err != nil {
+		return fmt.Errorf("failed to load spec: %w", err)
+	}
+
+	if err := swagger.Validate(context.Background()); err != nil {
+		return fmt.Errorf("invalid spec: %w", err)
+	}
+
+	router, err := v.newRouter(swagger)
+	if err != nil {
+		return fmt.Errorf("failed to create router: %w", err)
+	}
+
+	v.state.Store(&specState{swagger: swagger, router: router})
+	return nil
+}
+
+// Watch polls the spec source every interval and calls Reload whenever its modification time or size changes.
+// onReload, if not nil, is called after every reload attempt with its result (nil on success).
+// Watch blocks until ctx is cancelled and is typically run in its own goroutine:
+//
+//	go v.Watch(ctx, 5*time.Second, func(err error) { ... })
+//
+// Watch returns an error immediately if the spec source cannot be watched (e.g. NewFromBytes).
+func (v *Validator) Watch(ctx context.Context, interval time.Duration, onReload func(error)) error {
+	if v.source.stat == nil {
+		return errors.New("watch: spec source cannot be watched")
+	}
+	if interval <= 0 {
+		return errors.New("watch: interval must be positive")
+	}
+
+	last, err := v.source.stat()
+	if err != nil {
+		return fmt.Errorf("watch: %w", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		info, err := v.source.stat()
+		if err != nil {
+			// The file may be in the middle of being replaced; try again on the next tick.
+			continue
+		}
+		if info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size() {
+			continue
+		}
+		last = info
+
+		err = v.Reload()
+		if onReload != nil {
+			onReload(err)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package openapi_validator
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+const testSpecV2 = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 2.0.0
+paths:
+  /test:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name, age]
+              properties:
+                name: {type: string}
+                age: {type: integer}
+      responses:
+        '200':
+          description: OK
+`
+
+func TestValidator_Reload(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(testSpec), 0644); err != nil {
+		t.Fatalf("failed to write temp spec: %v", err)
+	}
+
+	v, err := New(specPath)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func() int {
+		req := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"test"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("Swaps Spec And Router", func(t *testing.T) {
+		// Arrange
+		os.WriteFile(specPath, []byte(testSpecV2), 0644)
+
+		// Act
+		err := v.Reload()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected reload error: %v", err)
+		}
+
+		if v.Spec().Info.Version != "2.0.0" {
+			t.Errorf("expected spec version 2.0.0, got %s", v.Spec().Info.Version)
+		}
+
+		if code := send(); code != http.StatusBadRequest {
+			t.Errorf("expected status 400 with the new required field, got %d", code)
+		}
+	})
+
+	t.Run("Keeps Previous Spec On Invalid Reload", func(t *testing.T) {
+		// Arrange
+		os.WriteFile(specPath, []byte("invalid yaml content"), 0644)
+
+		// Act
+		err := v.Reload()
+
+		// Assert
+		if err == nil {
+			t.Error("expected error for invalid spec")
+		}
+
+		if v.Spec().Info.Version != "2.0.0" {
+			t.Errorf("expected spec version 2.0.0 to be kept, got %s", v.Spec().Info.Version)
+		}
+	})
+
+	t.Run("Serves Reloaded Spec In Swagger UI", func(t *testing.T) {
+		// Arrange
+		req := httptest.NewRequest("GET", "/docs/openapi.json", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		v.SwaggerUIHandler().ServeHTTP(w, req)
+
+		// Assert
+		if !strings.Contains(w.Body.String(), `"version":"2.0.0"`) {
+			t.Errorf("expected served spec to be version 2.0.0, got %s", w.Body.String())
+		}
+	})
+}
+
+// mapRouter is a router whose dynamic type is not comparable.
+type mapRouter struct {
+	routers.Router
+	tags map[string]string
+}
+
+func TestValidator_Reload_CustomRouter(t *testing.T) {
+	t.Run("Rejects Router Supplied To New", func(t *testing.T) {
+		// Arrange
+		doc, _ := openapi3.NewLoader().LoadFromData([]byte(testSpec))
+		router, _ := legacy.NewRouter(doc)
+		v, err := NewFromBytes([]byte(testSpec), WithRouter(mapRouter{Router: router}))
+		if err != nil {
+			t.Fatalf("failed to create validator: %v", err)
+		}
+
+		// Act
+		err = v.Reload()
+
+		// Assert
+		if err == nil {
+			t.Error("expected error when reloading with a custom router")
+		}
+	})
+
+	t.Run("Uses Router Factory", func(t *testing.T) {
+		// Arrange
+		calls := 0
+		v, err := NewFromBytes([]byte(testSpec), WithRouterFactory(func(doc *openapi3.T) (routers.Router, error) {
+			calls++
+			router, err := legacy.NewRouter(doc)
+			return mapRouter{Router: router}, err
+		}))
+		if err != nil {
+			t.Fatalf("failed to create validator: %v", err)
+		}
+
+		// Act
+		err = v.Reload()
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected reload error: %v", err)
+		}
+
+		if calls != 2 {
+			t.Errorf("expected factory to be called twice, got %d", calls)
+		}
+	})
+}
+
+func TestValidator_Watch(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	os.WriteFile(specPath, []byte(testSpec), 0644)
+
+	v, err := New(specPath)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	t.Run("Reloads On Change", func(t *testing.T) {
+		// Arrange
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		reloaded := make(chan error, 1)
+		go v.Watch(ctx, 10*time.Millisecond, func(err error) {
+			reloaded <- err
+		})
+
+		// Act
+		time.Sleep(50 * time.Millisecond)
+		os.WriteFile(specPath, []byte(testSpecV2), 0644)
+
+		// Assert
+		select {
+		case err := <-reloaded:
+			if err != nil {
+				t.Fatalf("unexpected reload error: %v", err)
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for reload")
+		}
+
+		if v.Spec().Info.Version != "2.0.0" {
+			t.Errorf("expected spec version 2.0.0, got %s", v.Spec().Info.Version)
+		}
+	})
+
+	t.Run("Unsupported Source", func(t *testing.T) {
+		// Arrange
+		v, _ := NewFromBytes([]byte(testSpec))
+
+		// Act
+		err := v.Watch(context.Background(), time.Second, nil)
+
+		// Assert
+		if err == nil {
+			t.Error("expected error for a source that cannot be watched")
+		}
+	})
+}
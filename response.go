@@ -0,0 +1,359 @@
+package openapi_validator
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// ErrValidationSkipped is reported to the ResponseErrorHandler, wrapped with the reason,
+// when a response could not be validated, e.g. because its body exceeds MaxResponseBodyBytes.
+var ErrValidationSkipped = errors.New("response validation skipped")
+
+// ServeResponse calls next and validates the response it produced against route, as Middleware
+// does when ValidateResponses is enabled. In strict mode the response is held back until it has
+// been validated, and an invalid response is replaced by an error response with
+// Options.ResponseErrorStatus. Adapters can call it after ValidateRequest.
+func (v *Validator) ServeResponse(w http.ResponseWriter, r *http.Request, next http.Handler, route *routers.Route, pathParams map[string]string) {
+	rw := &responseWriter{
+		ResponseWriter: w,
+		buffer:         v.Options.StrictResponses,
+		maxBody:        v.Options.MaxResponseBodyBytes,
+		streamingTypes: v.Options.StreamingMediaTypes,
+		route:          route,
+		report: func(status int, err error) {
+			if v.Options.ResponseErrorHandler != nil {
+				v.Options.ResponseErrorHandler(r, status, err)
+			}
+		},
+	}
+	if rw.buffer {
+		rw.header = make(http.Header)
+	}
+	next.ServeHTTP(rw.wrap(), r)
+
+	// A hijacked connection (e.g. a websocket upgrade) is no longer an HTTP response.
+	if rw.hijacked {
+		return
+	}
+
+	// Streamed responses were passed through as they were written; event streams were validated per event.
+	if rw.streaming {
+		return
+	}
+
+	if rw.skipReason != "" {
+		rw.report(rw.statusCode(), fmt.Errorf("%w: %s", ErrValidationSkipped, rw.skipReason))
+		return
+	}
+
+	err := v.ValidateResponse(r, route, pathParams, rw.statusCode(), rw.Header(), rw.body)
+	if err != nil {
+		rw.report(rw.statusCode(), err)
+	}
+
+	if !rw.buffer {
+		// NOTE: We already sent the response to the user,
+		// so the error handler is the only place the violation surfaces.
+		return
+	}
+
+	if err != nil {
+		status := v.Options.ResponseErrorStatus
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		v.Options.ErrorEncoder(w, r, &HTTPError{Status: status, Err: err})
+		return
+	}
+
+	rw.commit()
+}
+
+// ValidateResponse validates a response with the given status, header and body against route,
+// for adapters that record responses themselves.
+func (v *Validator) ValidateResponse(r *http.Request, route *routers.Route, pathParams map[string]string, status int, header http.Header, body []byte) error {
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status: status,
+		Header: header,
+	}
+	if body != nil {
+		input.SetBodyBytes(body)
+	}
+	return openapi3filter.ValidateResponse(context.Background(), input)
+}
+
+// ResponseErrorHandler is a function type called whenever a response fails validation,
+// with the request, the status code written by the handler and the validation error.
+// Responses that could not be validated are reported with an error wrapping ErrValidationSkipped.
+type ResponseErrorHandler func(r *http.Request, status int, err error)
+
+// DefaultResponseErrorHandler is the built-in ResponseErrorHandler. It logs the violation
+// with log/slog at error level, and skipped validations at info level.
+func DefaultResponseErrorHandler(r *http.Request, status int, err error) {
+	level, msg := slog.LevelError, "response validation failed"
+	if errors.Is(err, ErrValidationSkipped) {
+		level, msg = slog.LevelInfo, "response validation skipped"
+	}
+	slog.Log(r.Context(), level, msg,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", status,
+		"error", err,
+	)
+}
+
+// responseWriter records the status, headers and body written by a handler so the response
+// can be validated. When buffer is set, nothing reaches the underlying writer until commit.
+// Responses with a streaming media type, or whose body grows beyond maxBody, are passed
+// through without being recorded.
+type responseWriter struct {
+	http.ResponseWriter
+	status   int
+	body     []byte
+	header   http.Header
+	buffer   bool
+	hijacked bool
+
+	maxBody        int64
+	streamingTypes []string
+	route          *routers.Route
+	report         func(status int, err error)
+
+	// streaming is set when the response uses a streaming media type.
+	streaming bool
+	// events validates the data of text/event-stream responses, one event at a time.
+	events *eventStreamValidator
+	// skipReason explains why the response will not be validated.
+	skipReason string
+}
+
+// Unwrap returns the underlying http.ResponseWriter, for use by http.ResponseController.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+func (rw *responseWriter) Header() http.Header {
+	if rw.buffer {
+		return rw.header
+	}
+	return rw.ResponseWriter.Header()
+}
+
+func (rw *responseWriter) WriteHeader(status int) {
+	if status >= 100 && status < 200 && status != http.StatusSwitchingProtocols {
+		// Informational responses (e.g. 103 Early Hints) precede the final status.
+		if !rw.buffer {
+			rw.ResponseWriter.WriteHeader(status)
+		}
+		return
+	}
+	if rw.status != 0 {
+		return
+	}
+	rw.status = status
+
+	if mediaType := rw.streamingMediaType(); mediaType != "" {
+		rw.streaming = true
+		if mediaType == "text/event-stream" {
+			rw.events = newEventStreamValidator(rw.route, status, func(err error) {
+				rw.report(status, err)
+			})
+		}
+		// Streams cannot be held back until they are complete.
+		if rw.buffer {
+			rw.commit()
+			return
+		}
+	}
+
+	if !rw.buffer {
+		rw.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+
+	if rw.streaming {
+		if rw.events != nil {
+			rw.events.Write(b)
+		}
+		return rw.ResponseWriter.Write(b)
+	}
+
+	if rw.skipReason == "" {
+		if rw.maxBody > 0 && int64(len(rw.body)+len(b)) > rw.maxBody {
+			rw.skipReason = fmt.Sprintf("response body exceeds %d bytes", rw.maxBody)
+			if rw.buffer {
+				rw.commit()
+			}
+			rw.body = nil
+		} else {
+			rw.body = append(rw.body, b...)
+		}
+	}
+
+	if rw.buffer {
+		return len(b), nil
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+// streamingMediaType returns the media type of the response if it is one of the streaming media types.
+func (rw *responseWriter) streamingMediaType() string {
+	return streamingMediaType(rw.Header(), rw.streamingTypes)
+}
+
+// streamingMediaType returns the media type of a response with header if it is one of streamingTypes.
+func streamingMediaType(header http.Header, streamingTypes []string) string {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	for _, streamingType := range streamingTypes {
+		if strings.EqualFold(mediaType, streamingType) {
+			return strings.ToLower(mediaType)
+		}
+	}
+	return ""
+}
+
+func (rw *responseWriter) flush() {
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+	// A buffered response cannot be sent before it has been validated.
+	if !rw.buffer {
+		rw.ResponseWriter.(http.Flusher).Flush()
+	}
+}
+
+func (rw *responseWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := rw.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil {
+		rw.hijacked = true
+	}
+	return conn, brw, err
+}
+
+func (rw *responseWriter) readFrom(src io.Reader) (int64, error) {
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+	// Keep the underlying fast path (e.g. sendfile) when the body does not need to be inspected.
+	if !rw.buffer && rw.events == nil && (rw.streaming || rw.skipReason != "") {
+		return rw.ResponseWriter.(io.ReaderFrom).ReadFrom(src)
+	}
+	return io.Copy(writerOnly{rw}, src)
+}
+
+// writerOnly hides every method but Write, so io.Copy does not loop back into readFrom.
+type writerOnly struct {
+	io.Writer
+}
+
+// statusCode returns the status written by the handler, defaulting to 200 OK like net/http does.
+func (rw *responseWriter) statusCode() int {
+	if rw.status == 0 {
+		return http.StatusOK
+	}
+	return rw.status
+}
+
+// commit sends a buffered response to the underlying writer and stops buffering.
+func (rw *responseWriter) commit() {
+	dst := rw.ResponseWriter.Header()
+	for k, values := range rw.header {
+		dst[k] = values
+	}
+	rw.buffer = false
+	rw.ResponseWriter.WriteHeader(rw.statusCode())
+	if len(rw.body) > 0 {
+		rw.ResponseWriter.Write(rw.body)
+	}
+}
+
+type responseFlusher struct{ rw *responseWriter }
+
+func (f responseFlusher) Flush() { f.rw.flush() }
+
+type responseHijacker struct{ rw *responseWriter }
+
+func (h responseHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) { return h.rw.hijack() }
+
+type responseReaderFrom struct{ rw *responseWriter }
+
+func (r responseReaderFrom) ReadFrom(src io.Reader) (int64, error) { return r.rw.readFrom(src) }
+
+// wrap returns rw as an http.ResponseWriter implementing exactly the optional interfaces
+// (http.Flusher, http.Hijacker and io.ReaderFrom) supported by the underlying writer,
+// so handlers relying on type assertions keep working behind the middleware.
+func (rw *responseWriter) wrap() http.ResponseWriter {
+	_, canFlush := rw.ResponseWriter.(http.Flusher)
+	_, canHijack := rw.ResponseWriter.(http.Hijacker)
+	_, canReadFrom := rw.ResponseWriter.(io.ReaderFrom)
+
+	f, h, r := responseFlusher{rw}, responseHijacker{rw}, responseReaderFrom{rw}
+	switch {
+	case canFlush && canHijack && canReadFrom:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+			io.ReaderFrom
+		}{rw, f, h, r}
+	case canFlush && canHijack:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+		}{rw, f, h}
+	case canFlush && canReadFrom:
+		return struct {
+			*responseWriter
+			http.Flusher
+			io.ReaderFrom
+		}{rw, f, r}
+	case canHijack && canReadFrom:
+		return struct {
+			*responseWriter
+			http.Hijacker
+			io.ReaderFrom
+		}{rw, h, r}
+	case canFlush:
+		return struct {
+			*responseWriter
+			http.Flusher
+		}{rw, f}
+	case canHijack:
+		return struct {
+			*responseWriter
+			http.Hijacker
+		}{rw, h}
+	case canReadFrom:
+		return struct {
+			*responseWriter
+			io.ReaderFrom
+		}{rw, r}
+	default:
+		return rw
+	}
+}
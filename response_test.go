@@ -0,0 +1,401 @@
+package openapi_validator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidator_Middleware_StrictResponses(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"test"}`))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	respond := func(body string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Request-Id", "abc")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(body))
+		})
+	}
+
+	t.Run("Valid Response Is Committed", func(t *testing.T) {
+		// Arrange
+		v, _ := NewFromBytes([]byte(testSpec), WithStrictResponses(0))
+		w := httptest.NewRecorder()
+
+		// Act
+		v.Middleware(respond(`{"result":"ok"}`)).ServeHTTP(w, newRequest())
+
+		// Assert
+		if w.Code != http.StatusOK {
+			t.Errorf("expected status 200, got %d", w.Code)
+		}
+
+		if w.Body.String() != `{"result":"ok"}` {
+			t.Errorf("expected handler body, got %s", w.Body.String())
+		}
+
+		if w.Header().Get("X-Request-Id") != "abc" {
+			t.Error("expected handler headers to be committed")
+		}
+	})
+
+	t.Run("Invalid Response Is Replaced", func(t *testing.T) {
+		// Arrange
+		v, _ := NewFromBytes([]byte(testSpec), WithStrictResponses(0))
+		w := httptest.NewRecorder()
+
+		// Act
+		v.Middleware(respond(`{"result":42}`)).ServeHTTP(w, newRequest())
+
+		// Assert
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", w.Code)
+		}
+
+		if strings.Contains(w.Body.String(), `{"result":42}`) {
+			t.Errorf("expected invalid payload not to leak, got %s", w.Body.String())
+		}
+
+		if w.Header().Get("X-Request-Id") != "" {
+			t.Error("expected handler headers to be discarded")
+		}
+
+		var resp ValidationError
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if len(resp.Details) == 0 {
+			t.Error("expected details describing the invalid response")
+		}
+	})
+
+	t.Run("Custom Error Status", func(t *testing.T) {
+		// Arrange
+		v, _ := NewFromBytes([]byte(testSpec), WithStrictResponses(http.StatusBadGateway))
+		w := httptest.NewRecorder()
+
+		// Act
+		v.Middleware(respond(`{"result":42}`)).ServeHTTP(w, newRequest())
+
+		// Assert
+		if w.Code != http.StatusBadGateway {
+			t.Errorf("expected status 502, got %d", w.Code)
+		}
+	})
+}
+
+func TestValidator_Middleware_ResponseErrorHandler(t *testing.T) {
+	// Arrange
+	var (
+		calls     int
+		gotStatus int
+		gotErr    error
+	)
+	v, _ := NewFromBytes([]byte(testSpec),
+		WithValidateResponses(true),
+		WithResponseErrorHandler(func(r *http.Request, status int, err error) {
+			calls++
+			gotStatus = status
+			gotErr = err
+		}),
+	)
+
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"result":42}`))
+	}))
+
+	req := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"test"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, req)
+
+	// Assert
+	if w.Code != http.StatusOK {
+		t.Errorf("expected response to be sent unchanged, got %d", w.Code)
+	}
+
+	if calls != 1 || gotStatus != http.StatusOK || gotErr == nil {
+		t.Errorf("expected handler to be called once with status 200 and an error, got %d calls, status %d, err %v", calls, gotStatus, gotErr)
+	}
+}
+
+func TestDefaultResponseErrorHandler(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer slog.SetDefault(previous)
+
+	req := httptest.NewRequest("GET", "/items", nil)
+
+	// Act
+	DefaultResponseErrorHandler(req, http.StatusOK, errors.New("boom"))
+
+	// Assert
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to unmarshal log entry: %v", err)
+	}
+
+	if entry["level"] != "ERROR" || entry["path"] != "/items" || entry["error"] != "boom" {
+		t.Errorf("unexpected log entry %v", entry)
+	}
+}
+
+const testStreamingSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /events:
+    get:
+      responses:
+        '200':
+          description: Event stream
+          content:
+            text/event-stream:
+              schema: {type: string}
+  /ws:
+    get:
+      responses:
+        '101':
+          description: Switching Protocols
+  /file:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  result: {type: string}
+`
+
+func TestResponseWriter_Wrap(t *testing.T) {
+	// Arrange
+	rw := &responseWriter{ResponseWriter: httptest.NewRecorder()}
+
+	// Act
+	wrapped := rw.wrap()
+
+	// Assert
+	if _, ok := wrapped.(http.Flusher); !ok {
+		t.Error("expected wrapped writer to implement http.Flusher")
+	}
+
+	if _, ok := wrapped.(http.Hijacker); ok {
+		t.Error("expected wrapped writer not to implement http.Hijacker")
+	}
+
+	if _, ok := wrapped.(io.ReaderFrom); ok {
+		t.Error("expected wrapped writer not to implement io.ReaderFrom")
+	}
+
+	if u, ok := wrapped.(interface{ Unwrap() http.ResponseWriter }); !ok || u.Unwrap() != rw.ResponseWriter {
+		t.Error("expected wrapped writer to unwrap to the underlying writer")
+	}
+}
+
+func TestValidator_Middleware_OptionalInterfaces(t *testing.T) {
+	violations := make(chan error, 1)
+	v, err := NewFromBytes([]byte(testStreamingSpec),
+		WithValidateResponses(true),
+		WithResponseErrorHandler(func(r *http.Request, status int, err error) {
+			if r.URL.Path == "/file" {
+				violations <- err
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: first\n\n"))
+		w.(http.Flusher).Flush()
+		<-release
+		w.Write([]byte("data: second\n\n"))
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, brw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("failed to hijack: %v", err)
+			return
+		}
+		defer conn.Close()
+		brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		brw.Flush()
+		line, _ := brw.ReadString('\n')
+		brw.WriteString("echo: " + line)
+		brw.Flush()
+	})
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(time.Minute)); err != nil {
+			t.Errorf("expected ResponseController to reach the underlying writer: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.Copy(w, strings.NewReader(`{"result":42}`))
+	})
+
+	server := httptest.NewServer(v.Middleware(mux))
+	defer server.Close()
+
+	t.Run("Server-Sent Events Are Flushed", func(t *testing.T) {
+		// Arrange
+		defer close(release)
+
+		// Act
+		resp, err := http.Get(server.URL + "/events")
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		defer resp.Body.Close()
+		line, err := bufio.NewReader(resp.Body).ReadString('\n')
+
+		// Assert
+		if err != nil || line != "data: first\n" {
+			t.Errorf("expected first event before the handler returned, got %q (%v)", line, err)
+		}
+	})
+
+	t.Run("Websocket Upgrade Is Hijacked", func(t *testing.T) {
+		// Arrange
+		conn, err := net.Dial("tcp", server.Listener.Addr().String())
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		// Act
+		fmt.Fprintf(conn, "GET /ws HTTP/1.1\r\nHost: example.com\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		br := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(br, nil)
+		if err != nil {
+			t.Fatalf("failed to read upgrade response: %v", err)
+		}
+		fmt.Fprintf(conn, "hello\n")
+		echo, _ := br.ReadString('\n')
+
+		// Assert
+		if resp.StatusCode != http.StatusSwitchingProtocols {
+			t.Errorf("expected status 101, got %d", resp.StatusCode)
+		}
+
+		if echo != "echo: hello\n" {
+			t.Errorf("expected echo over the hijacked connection, got %q", echo)
+		}
+	})
+
+	t.Run("ReadFrom Body Is Validated", func(t *testing.T) {
+		// Act
+		resp, err := http.Get(server.URL + "/file")
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		// Assert
+		if string(body) != `{"result":42}` {
+			t.Errorf("expected body to be copied through, got %s", body)
+		}
+
+		select {
+		case <-violations:
+		case <-time.After(5 * time.Second):
+			t.Error("expected the copied body to be validated")
+		}
+	})
+}
+
+func TestValidator_Middleware_LargeAndStreamingResponses(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"name":"test"}`))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("Large Body Skips Validation", func(t *testing.T) {
+		// Arrange
+		var reported error
+		v, _ := NewFromBytes([]byte(testSpec),
+			WithStrictResponses(0),
+			WithMaxResponseBodyBytes(8),
+			WithResponseErrorHandler(func(r *http.Request, status int, err error) { reported = err }),
+		)
+		handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"result":`))
+			w.Write([]byte(`42}`))
+		}))
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, newRequest())
+
+		// Assert
+		if w.Code != http.StatusOK || w.Body.String() != `{"result":42}` {
+			t.Errorf("expected the response to be passed through, got %d %s", w.Code, w.Body.String())
+		}
+
+		if !errors.Is(reported, ErrValidationSkipped) {
+			t.Errorf("expected a skipped validation to be reported, got %v", reported)
+		}
+	})
+
+	t.Run("Streaming Media Type Bypasses Buffering", func(t *testing.T) {
+		// Arrange
+		var reported []error
+		v, _ := NewFromBytes([]byte(testStreamingSpec),
+			WithStrictResponses(0),
+			WithResponseErrorHandler(func(r *http.Request, status int, err error) { reported = append(reported, err) }),
+		)
+		var flushedBeforeReturn bool
+		w := httptest.NewRecorder()
+		handler := v.Middleware(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Header().Set("Content-Type", "text/event-stream")
+			rw.Write([]byte("data: first\n\n"))
+			rw.(http.Flusher).Flush()
+			flushedBeforeReturn = w.Flushed && strings.Contains(w.Body.String(), "first")
+		}))
+
+		// Act
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/events", nil))
+
+		// Assert
+		if !flushedBeforeReturn {
+			t.Error("expected the event to reach the client before the handler returned")
+		}
+
+		if len(reported) != 0 {
+			t.Errorf("expected no violations for a string event stream, got %v", reported)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package openapi_validator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+// ViolationError reports an outgoing request, or the response received for it, that does not
+// match the spec. Response is nil for request violations.
+type ViolationError struct {
+	Request  *http.Request
+	Response *http.Response
+	Err      error
+}
+
+func (e *ViolationError) Error() string {
+	if e.Response != nil {
+		return fmt.Sprintf("response %d to %s %s violates the spec: %v", e.Response.StatusCode, e.Request.Method, e.Request.URL, e.Err)
+	}
+	return fmt.Sprintf("request %s %s violates the spec: %v", e.Request.Method, e.Request.URL, e.Err)
+}
+
+func (e *ViolationError) Unwrap() error {
+	return e.Err
+}
+
+// ViolationHandler is a function type called with every violation found by a RoundTripper.
+type ViolationHandler func(err *ViolationError)
+
+// DefaultViolationHandler is the built-in ViolationHandler. It logs the violation with
+// log/slog at warning level.
+func DefaultViolationHandler(err *ViolationError) {
+	slog.WarnContext(err.Request.Context(), "outbound API contract violation",
+		"method", err.Request.Method,
+		"url", err.Request.URL.String(),
+		"error", err.Err,
+	)
+}
+
+// RoundTripperOption is a function type used to configure a RoundTripper.
+type RoundTripperOption func(*roundTripper)
+
+// WithViolationHandler returns a RoundTripperOption that sets the handler notified of violations.
+func WithViolationHandler(handler ViolationHandler) RoundTripperOption {
+	return func(rt *roundTripper) {
+		rt.handler = handler
+	}
+}
+
+// WithStrictRoundTrips returns a RoundTripperOption that fails round trips with a *ViolationError:
+// invalid requests are not sent, and invalid responses are discarded.
+func WithStrictRoundTrips() RoundTripperOption {
+	return func(rt *roundTripper) {
+		rt.strict = true
+	}
+}
+
+// roundTripper validates the requests sent and responses received through base.
+type roundTripper struct {
+	v       *Validator
+	base    http.RoundTripper
+	handler ViolationHandler
+	strict  bool
+}
+
+// RoundTripper returns an http.RoundTripper validating outgoing requests, and the responses
+// received for them, against the spec, for clients of third-party APIs. Requests are matched
+// against the spec's servers; requests matching no operation are subject to the
+// UnmatchedRoutePolicy. Violations go to the ViolationHandler, or fail the round trip with a
+// *ViolationError in strict mode; response bodies are restored for the caller. Responses larger
+// than MaxResponseBodyBytes are passed through and reported with ErrValidationSkipped, even in
+// strict mode. A nil base means http.DefaultTransport.
+func (v *Validator) RoundTripper(base http.RoundTripper, opts ...RoundTripperOption) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	rt := &roundTripper{v: v, base: base, handler: DefaultViolationHandler}
+	for _, opt := range opts {
+		opt(rt)
+	}
+	return rt
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request, so validate and send a copy. The copy shares
+	// req.Body, which is consumed and closed here, as the transport would, and then restored on
+	// the copy from the buffered bytes; req.Body itself is not replaced.
+	out := req.Clone(req.Context())
+	body, err := bufferBody(out)
+	if err != nil {
+		return nil, err
+	}
+	restoreBody(out, body)
+
+	route, pathParams, err := rt.v.router().FindRoute(out)
+	if err != nil {
+		if rejection := rt.v.UnmatchedRoute(out, err); rejection != nil {
+			if verr := rt.violation(req, nil, rejection); verr != nil {
+				return nil, verr
+			}
+		}
+		return rt.base.RoundTrip(out)
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    out,
+		PathParams: pathParams,
+		Route:      route,
+		// Outgoing requests are validated as they are, credentials included.
+		Options: &openapi3filter.Options{
+			MultiError:          true,
+			SkipSettingDefaults: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+	if err := openapi3filter.ValidateRequest(out.Context(), input); err != nil {
+		if verr := rt.violation(req, nil, err); verr != nil {
+			return nil, verr
+		}
+	}
+
+	resp, err := rt.base.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusSwitchingProtocols || streamingMediaType(resp.Header, rt.v.Options.StreamingMediaTypes) != "" {
+		return resp, nil
+	}
+
+	data, complete, err := readUpTo(resp.Body, rt.v.Options.MaxResponseBodyBytes)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if !complete {
+		// Hand the rest of the body to the caller without holding it in memory.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+		if rt.handler != nil {
+			rt.handler(&ViolationError{Request: req, Response: resp, Err: fmt.Errorf("%w: response body exceeds %d bytes", ErrValidationSkipped, rt.v.Options.MaxResponseBodyBytes)})
+		}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	if err := rt.v.ValidateResponse(out, route, pathParams, resp.StatusCode, resp.Header, data); err != nil {
+		if verr := rt.violation(req, resp, err); verr != nil {
+			return nil, verr
+		}
+	}
+	return resp, nil
+}
+
+// violation reports err and returns it as a *ViolationError in strict mode.
+func (rt *roundTripper) violation(req *http.Request, resp *http.Response, err error) error {
+	verr := &ViolationError{Request: req, Response: resp, Err: err}
+	if rt.handler != nil {
+		rt.handler(verr)
+	}
+	if rt.strict {
+		return verr
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package openapi_validator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const testRoundTripSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: %s/v1
+paths:
+  /items/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id: {type: integer}
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name: {type: string}
+      responses:
+        '201':
+          description: Created
+`
+
+// newTestRoundTripClient starts an API answering /v1/items/1 with a valid body and any other
+// item with an invalid one, and returns a client validating against it.
+func newTestRoundTripClient(t *testing.T, hits *atomic.Int32, opts ...RoundTripperOption) (*http.Client, string) {
+	t.Helper()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/items/1" {
+			w.Write([]byte(`{"id":1}`))
+			return
+		}
+		w.Write([]byte(`{"id":"oops"}`))
+	}))
+	t.Cleanup(api.Close)
+
+	v, err := NewFromBytes([]byte(fmt.Sprintf(testRoundTripSpec, api.URL)))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	return &http.Client{Transport: v.RoundTripper(nil, opts...)}, api.URL
+}
+
+func TestRoundTripper(t *testing.T) {
+	t.Run("Report", func(t *testing.T) {
+		var violations []*ViolationError
+		var hits atomic.Int32
+		client, baseURL := newTestRoundTripClient(t, &hits, WithViolationHandler(func(err *ViolationError) {
+			violations = append(violations, err)
+		}))
+
+		t.Run("Valid Exchange", func(t *testing.T) {
+			// Arrange
+			violations = nil
+
+			// Act
+			resp, err := client.Get(baseURL + "/v1/items/1")
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(body) != `{"id":1}` {
+				t.Errorf("expected the response body to be restored, got %q", body)
+			}
+			if len(violations) != 0 {
+				t.Errorf("expected no violations, got %v", violations)
+			}
+		})
+
+		t.Run("Invalid Request Is Sent", func(t *testing.T) {
+			// Arrange
+			violations = nil
+			hits.Store(0)
+
+			// Act
+			resp, err := client.Post(baseURL+"/v1/items", "application/json", strings.NewReader(`{"name":1}`))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(body) != `{"name":1}` {
+				t.Errorf("expected the request body to reach the server, got %q", body)
+			}
+			if hits.Load() != 1 {
+				t.Error("expected the request to be sent")
+			}
+			if len(violations) != 1 || violations[0].Response != nil {
+				t.Errorf("expected one request violation, got %v", violations)
+			}
+		})
+
+		t.Run("Invalid Response Is Returned", func(t *testing.T) {
+			// Arrange
+			violations = nil
+
+			// Act
+			resp, err := client.Get(baseURL + "/v1/items/2")
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if string(body) != `{"id":"oops"}` {
+				t.Errorf("expected the response body to be restored, got %q", body)
+			}
+			if len(violations) != 1 || violations[0].Response == nil {
+				t.Errorf("expected one response violation, got %v", violations)
+			}
+		})
+
+		t.Run("Other Servers Are Not Validated", func(t *testing.T) {
+			// Arrange
+			violations = nil
+
+			// Act
+			resp, err := client.Get(baseURL + "/v2/items/abc")
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if len(violations) != 0 {
+				t.Errorf("expected no violations, got %v", violations)
+			}
+		})
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		var hits atomic.Int32
+		client, baseURL := newTestRoundTripClient(t, &hits, WithViolationHandler(nil), WithStrictRoundTrips())
+
+		t.Run("Invalid Request Is Not Sent", func(t *testing.T) {
+			// Arrange
+			hits.Store(0)
+
+			// Act
+			_, err := client.Get(baseURL + "/v1/items/abc")
+
+			// Assert
+			var verr *ViolationError
+			if !errors.As(err, &verr) || verr.Response != nil {
+				t.Errorf("expected a request *ViolationError, got %v", err)
+			}
+			if hits.Load() != 0 {
+				t.Error("expected the request not to be sent")
+			}
+		})
+
+		t.Run("Invalid Response Fails", func(t *testing.T) {
+			// Act
+			_, err := client.Get(baseURL + "/v1/items/2")
+
+			// Assert
+			var verr *ViolationError
+			if !errors.As(err, &verr) || verr.Response == nil || verr.Response.StatusCode != http.StatusOK {
+				t.Errorf("expected a response *ViolationError, got %v", err)
+			}
+		})
+	})
+}
+
+func TestRoundTripperLeavesRequestIntact(t *testing.T) {
+	// Arrange
+	var hits atomic.Int32
+	client, baseURL := newTestRoundTripClient(t, &hits, WithViolationHandler(nil))
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/v1/items", strings.NewReader(`{"name":"a"}`))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	body := req.Body
+
+	// Act
+	resp, err := client.Do(req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.Request.Header.Get("Content-Type") != "application/json" || len(req.Header) != 1 {
+		t.Errorf("expected the request headers to be unchanged, got %v", req.Header)
+	}
+	if req.Body != body {
+		t.Error("expected req.Body not to be replaced")
+	}
+}
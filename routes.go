@@ -0,0 +1,166 @@
+package openapi_validator
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// routeMethods lists the HTTP methods an OpenAPI path item can declare.
+var routeMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodTrace,
+	http.MethodConnect,
+}
+
+// handleUnmatchedRoute applies the UnmatchedRoutePolicy to a request the router could not match.
+// It reports whether the request should be forwarded to the next handler.
+func (v *Validator) handleUnmatchedRoute(w http.ResponseWriter, r *http.Request, err error) bool {
+	rejection := v.UnmatchedRoute(r, err)
+	if rejection == nil {
+		return true
+	}
+	if v.Options.UnmatchedRoutePolicy == UnmatchedRouteReject && isMethodNotAllowed(err) {
+		if allow := v.allowedMethods(r); len(allow) > 0 {
+			w.Header().Set("Allow", strings.Join(allow, ", "))
+		}
+	}
+	v.Options.ErrorEncoder(w, r, rejection)
+	return false
+}
+
+// UnmatchedRoute applies the UnmatchedRoutePolicy to r, which matched no operation with the
+// lookup error err. It returns nil when the request should be passed on to the next handler,
+// and otherwise the *HTTPError to reply with.
+func (v *Validator) UnmatchedRoute(r *http.Request, err error) error {
+	switch v.Options.UnmatchedRoutePolicy {
+	case UnmatchedRouteLog:
+		slog.Warn("request does not match any OpenAPI operation",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"error", err,
+		)
+		return nil
+	case UnmatchedRouteNotFound:
+		// Report the path as unknown regardless of the router's verdict.
+		return v.httpError(routers.ErrPathNotFound)
+	case UnmatchedRouteReject:
+		return v.httpError(err)
+	default:
+		return nil
+	}
+}
+
+// isMethodNotAllowed reports whether err means the path exists but the method is not declared.
+// Some routers return a fresh RouteError instead of the routers.ErrMethodNotAllowed sentinel,
+// so the reason is compared as well.
+func isMethodNotAllowed(err error) bool {
+	if errors.Is(err, routers.ErrMethodNotAllowed) {
+		return true
+	}
+	var routeErr *routers.RouteError
+	return errors.As(err, &routeErr) && routeErr.Reason == routers.ErrMethodNotAllowed.Error()
+}
+
+// allowedMethods returns the sorted methods declared on the path item matching r, if any.
+// The path item is found by probing the router with each method, so it works with any router.
+func (v *Validator) allowedMethods(r *http.Request) []string {
+	router := v.router()
+	for _, method := range routeMethods {
+		probe := r.Clone(r.Context())
+		probe.Method = method
+		route, _, err := router.FindRoute(probe)
+		if err != nil || route.PathItem == nil {
+			continue
+		}
+
+		var methods []string
+		for m := range route.PathItem.Operations() {
+			methods = append(methods, m)
+		}
+		sort.Strings(methods)
+		return methods
+	}
+	return nil
+}
+
+// FindRoute matches r against the spec with the router currently in use, as Middleware does.
+// It returns routers.ErrPathNotFound or routers.ErrMethodNotAllowed when no operation matches.
+func (v *Validator) FindRoute(r *http.Request) (*routers.Route, map[string]string, error) {
+	return v.router().FindRoute(r)
+}
+
+// OperationRoute returns the route of the operation declared for method on the OpenAPI path
+// template path (e.g. "/users/{id}"; parameter names need not match the spec), also trying path
+// below the base paths of the spec's servers. It returns routers.ErrPathNotFound or
+// routers.ErrMethodNotAllowed when the spec declares no such operation.
+func (v *Validator) OperationRoute(method, path string) (*routers.Route, error) {
+	doc := v.Spec()
+	pathItem, template := findPathItem(doc, path)
+	if pathItem == nil {
+		return nil, routers.ErrPathNotFound
+	}
+	operation := pathItem.GetOperation(method)
+	if operation == nil {
+		return nil, routers.ErrMethodNotAllowed
+	}
+	return &routers.Route{
+		Spec:      doc,
+		Path:      template,
+		PathItem:  pathItem,
+		Method:    method,
+		Operation: operation,
+	}, nil
+}
+
+// PathMethods returns the sorted methods the spec declares for the OpenAPI path template path,
+// for use in an Allow header.
+func (v *Validator) PathMethods(path string) []string {
+	pathItem, _ := findPathItem(v.Spec(), path)
+	if pathItem == nil {
+		return nil
+	}
+	return mapkeys.Sorted(pathItem.Operations())
+}
+
+// findPathItem returns the path item matching the path template path, directly or below the
+// base path of one of the servers, together with its template as written in the spec.
+func findPathItem(doc *openapi3.T, path string) (*openapi3.PathItem, string) {
+	if doc.Paths == nil {
+		return nil, ""
+	}
+
+	candidates := []string{path}
+	for _, server := range doc.Servers {
+		basePath, err := server.BasePath()
+		if err == nil && basePath != "/" && strings.HasPrefix(path, basePath+"/") {
+			candidates = append(candidates, strings.TrimPrefix(path, basePath))
+		}
+	}
+
+	for _, candidate := range candidates {
+		pathItem := doc.Paths.Find(candidate)
+		if pathItem == nil {
+			continue
+		}
+		for template, item := range doc.Paths.Map() {
+			if item == pathItem {
+				return pathItem, template
+			}
+		}
+		return pathItem, candidate
+	}
+	return nil, ""
+}
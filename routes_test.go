@@ -0,0 +1,180 @@
+package openapi_validator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+const testRoutesSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      responses:
+        '201':
+          description: Created
+`
+
+func TestValidator_Middleware_UnmatchedRoutePolicy(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	newHandler := func(t *testing.T, opts ...Option) http.Handler {
+		v, err := NewFromBytes([]byte(testRoutesSpec), opts...)
+		if err != nil {
+			t.Fatalf("failed to create validator: %v", err)
+		}
+		return v.Middleware(next)
+	}
+
+	t.Run("Pass Through By Default", func(t *testing.T) {
+		// Arrange
+		handler := newHandler(t)
+		req := httptest.NewRequest("GET", "/unknown", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusTeapot {
+			t.Errorf("expected request to reach the handler, got %d", w.Code)
+		}
+	})
+
+	t.Run("Reject Unknown Path", func(t *testing.T) {
+		// Arrange
+		handler := newHandler(t, WithUnmatchedRoutePolicy(UnmatchedRouteReject))
+		req := httptest.NewRequest("GET", "/unknown", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Reject Unknown Method With Allow Header", func(t *testing.T) {
+		// Arrange
+		handler := newHandler(t, WithUnmatchedRoutePolicy(UnmatchedRouteReject))
+		req := httptest.NewRequest("DELETE", "/items", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("expected status 405, got %d", w.Code)
+		}
+
+		if allow := w.Header().Get("Allow"); allow != "GET, POST" {
+			t.Errorf("expected Allow header 'GET, POST', got %q", allow)
+		}
+	})
+
+	t.Run("Reject Unknown Method With Legacy Router", func(t *testing.T) {
+		// Arrange
+		handler := newHandler(t,
+			WithUnmatchedRoutePolicy(UnmatchedRouteReject),
+			WithRouterFactory(func(doc *openapi3.T) (routers.Router, error) { return legacy.NewRouter(doc) }),
+		)
+		req := httptest.NewRequest("DELETE", "/items", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("expected status 405, got %d", w.Code)
+		}
+	})
+
+	t.Run("Not Found Hides Methods", func(t *testing.T) {
+		// Arrange
+		handler := newHandler(t, WithUnmatchedRoutePolicy(UnmatchedRouteNotFound))
+		req := httptest.NewRequest("DELETE", "/items", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+
+		if allow := w.Header().Get("Allow"); allow != "" {
+			t.Errorf("expected no Allow header, got %q", allow)
+		}
+	})
+
+	t.Run("Log Only", func(t *testing.T) {
+		// Arrange
+		handler := newHandler(t, WithUnmatchedRoutePolicy(UnmatchedRouteLog))
+		req := httptest.NewRequest("GET", "/unknown", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusTeapot {
+			t.Errorf("expected request to reach the handler, got %d", w.Code)
+		}
+	})
+}
+
+func TestValidator_FindRoute(t *testing.T) {
+	v, err := NewFromBytes([]byte(testRoutesSpec))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		err    error
+	}{
+		{"Matched", "POST", "/items", nil},
+		{"Path Not Found", "GET", "/other", routers.ErrPathNotFound},
+		{"Method Not Allowed", "DELETE", "/items", routers.ErrMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			route, _, err := v.FindRoute(httptest.NewRequest(tt.method, tt.target, nil))
+
+			// Assert
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("expected %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil || route.Method != tt.method || route.Path != tt.target {
+				t.Errorf("expected the %s %s route, got %+v (%v)", tt.method, tt.target, route, err)
+			}
+		})
+	}
+}
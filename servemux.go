@@ -0,0 +1,187 @@
+package openapi_validator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/vihuvac/go-openapi-validator/internal/mapkeys"
+)
+
+// serveMuxRouter is a routers.Router matching requests with the method and wildcard patterns of
+// net/http's ServeMux (Go 1.22+), so operations are matched exactly as a ServeMux routes them.
+type serveMuxRouter struct {
+	mux    *http.ServeMux
+	routes map[string]*serveMuxRoute
+}
+
+// serveMuxRoute is an operation registered under a ServeMux pattern.
+type serveMuxRoute struct {
+	route *routers.Route
+	// segments holds the segments of the pattern path, with the OpenAPI parameter
+	// name for wildcard segments and an empty string for literal ones.
+	segments []string
+}
+
+// NewServeMuxRouter builds a routers.Router translating the OpenAPI path templates of doc to
+// http.ServeMux patterns such as "GET /users/{id}", registered below the base path of every
+// server of the spec. Only parameters spanning whole path segments are supported, and servers
+// are matched by base path, not by host. HEAD requests only match operations declaring HEAD,
+// although ServeMux lets GET patterns serve them. Templates ServeMux considers conflicting,
+// such as "/{a}/b" and "/a/{b}", make it fail with an error naming both. It can be used as a
+// RouterFactory; see WithStdlibRouting.
+func NewServeMuxRouter(doc *openapi3.T) (routers.Router, error) {
+	router := &serveMuxRouter{
+		mux:    http.NewServeMux(),
+		routes: make(map[string]*serveMuxRoute),
+	}
+
+	servers := doc.Servers
+	if len(servers) == 0 {
+		servers = openapi3.Servers{nil}
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Value(path)
+		segments, pattern, err := serveMuxPath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, method := range mapkeys.Sorted(pathItem.Operations()) {
+			// Several servers may share a base path, the first one wins.
+			seen := make(map[string]bool)
+			for _, server := range servers {
+				basePath, err := server.BasePath()
+				if err != nil {
+					return nil, err
+				}
+				basePath = strings.TrimSuffix(basePath, "/")
+				if seen[basePath] {
+					continue
+				}
+				seen[basePath] = true
+
+				muxPattern := method + " " + basePath + pattern
+				if err := router.handle(muxPattern); err != nil {
+					return nil, router.conflict(path, muxPattern, err)
+				}
+
+				var baseSegments []string
+				if basePath != "" {
+					baseSegments = make([]string, strings.Count(basePath, "/"))
+				}
+				router.routes[muxPattern] = &serveMuxRoute{
+					route: &routers.Route{
+						Spec:      doc,
+						Server:    server,
+						Path:      path,
+						PathItem:  pathItem,
+						Method:    method,
+						Operation: pathItem.GetOperation(method),
+					},
+					segments: append(baseSegments, segments...),
+				}
+			}
+		}
+	}
+	return router, nil
+}
+
+// handle registers pattern with the mux, reporting conflicting patterns as an error
+// instead of the panic ServeMux raises.
+func (router *serveMuxRouter) handle(pattern string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	router.mux.Handle(pattern, http.NotFoundHandler())
+	return nil
+}
+
+// conflict describes why pattern, registered for the template path, was rejected, naming the
+// template of the registered pattern it conflicts with.
+func (router *serveMuxRouter) conflict(path, pattern string, err error) error {
+	for _, registered := range mapkeys.Sorted(router.routes) {
+		mux := &serveMuxRouter{mux: http.NewServeMux()}
+		if mux.handle(registered) != nil || mux.handle(pattern) == nil {
+			continue
+		}
+		other := router.routes[registered].route
+		return fmt.Errorf("path %q conflicts with path %q: ServeMux cannot tell %s requests to them apart",
+			path, other.Path, other.Method)
+	}
+	return fmt.Errorf("path %q: %w", path, err)
+}
+
+// serveMuxPath translates an OpenAPI path template to the path of a ServeMux pattern. Wildcards
+// are renamed p0, p1, ... since parameter names need not be valid Go identifiers; the returned
+// segments map them back. A trailing slash only matches itself, as in OpenAPI.
+func serveMuxPath(path string) ([]string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	segments := make([]string, len(parts))
+	wildcards := 0
+	for i, part := range parts {
+		if !strings.Contains(part, "{") {
+			continue
+		}
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") || strings.Count(part, "{") != 1 {
+			return nil, "", fmt.Errorf("path %q: ServeMux patterns only support parameters spanning a whole segment", path)
+		}
+		segments[i] = part[1 : len(part)-1]
+		parts[i] = fmt.Sprintf("{p%d}", wildcards)
+		wildcards++
+	}
+
+	pattern := "/" + strings.Join(parts, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "{$}"
+	}
+	return segments, pattern, nil
+}
+
+// FindRoute returns the operation whose ServeMux pattern matches req, with its path parameters.
+func (router *serveMuxRouter) FindRoute(req *http.Request) (*routers.Route, map[string]string, error) {
+	_, pattern := router.mux.Handler(req)
+	matched, ok := router.routes[pattern]
+	// ServeMux serves HEAD with GET patterns, the spec only with HEAD operations.
+	if !ok || matched.route.Method != req.Method {
+		if router.matchesOtherMethod(req) {
+			return nil, nil, routers.ErrMethodNotAllowed
+		}
+		return nil, nil, routers.ErrPathNotFound
+	}
+
+	pathParams := make(map[string]string)
+	parts := strings.Split(strings.TrimPrefix(req.URL.EscapedPath(), "/"), "/")
+	for i, name := range matched.segments {
+		if name == "" || i >= len(parts) {
+			continue
+		}
+		value, err := url.PathUnescape(parts[i])
+		if err != nil {
+			value = parts[i]
+		}
+		pathParams[name] = value
+	}
+	return matched.route, pathParams, nil
+}
+
+// matchesOtherMethod reports whether the path of req is declared for another method.
+func (router *serveMuxRouter) matchesOtherMethod(req *http.Request) bool {
+	for _, method := range routeMethods {
+		if method == req.Method {
+			continue
+		}
+		probe := req.Clone(req.Context())
+		probe.Method = method
+		if _, pattern := router.mux.Handler(probe); router.routes[pattern] != nil {
+			return true
+		}
+	}
+	return false
+}
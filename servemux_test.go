@@ -0,0 +1,189 @@
+package openapi_validator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/routers"
+)
+
+const testServeMuxSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+  - url: /v2
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        '200': {description: OK}
+  /users/:
+    get:
+      operationId: listUsersSlash
+      responses:
+        '200': {description: OK}
+  /status:
+    get:
+      operationId: getStatus
+      responses:
+        '200': {description: OK}
+    head:
+      operationId: headStatus
+      responses:
+        '200': {description: OK}
+  /users/me:
+    get:
+      operationId: getMe
+      responses:
+        '200': {description: OK}
+  /users/{user-id}:
+    get:
+      operationId: getUser
+      parameters:
+        - {name: user-id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200': {description: OK}
+    delete:
+      operationId: deleteUser
+      parameters:
+        - {name: user-id, in: path, required: true, schema: {type: string}}
+      responses:
+        '204': {description: No Content}
+`
+
+func TestNewServeMuxRouter(t *testing.T) {
+	v, err := NewFromBytes([]byte(testServeMuxSpec), WithStdlibRouting())
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	router := v.router()
+
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		wantOp    string
+		wantParam string
+		wantErr   error
+	}{
+		{name: "Literal Path", method: "GET", target: "/v1/users", wantOp: "listUsers"},
+		{name: "Trailing Slash", method: "GET", target: "/v1/users/", wantOp: "listUsersSlash"},
+		{name: "Most Specific Pattern", method: "GET", target: "/v1/users/me", wantOp: "getMe"},
+		{name: "Wildcard", method: "GET", target: "/v1/users/a%20b", wantOp: "getUser", wantParam: "a b"},
+		{name: "Second Server", method: "DELETE", target: "/v2/users/42", wantOp: "deleteUser", wantParam: "42"},
+		{name: "Unknown Path", method: "GET", target: "/v1/orders", wantErr: routers.ErrPathNotFound},
+		{name: "Outside Base Path", method: "GET", target: "/users", wantErr: routers.ErrPathNotFound},
+		{name: "Wrong Method", method: "PUT", target: "/v1/users/42", wantErr: routers.ErrMethodNotAllowed},
+		{name: "Undeclared Head", method: "HEAD", target: "/v1/users/42", wantErr: routers.ErrMethodNotAllowed},
+		{name: "Declared Head", method: "HEAD", target: "/v1/status", wantOp: "headStatus"},
+		{name: "Get Beside Head", method: "GET", target: "/v1/status", wantOp: "getStatus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+
+			// Act
+			route, pathParams, err := router.FindRoute(req)
+
+			// Assert
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if route.Operation.OperationID != tt.wantOp {
+				t.Errorf("expected operation %s, got %s", tt.wantOp, route.Operation.OperationID)
+			}
+			if tt.wantParam != "" && pathParams["user-id"] != tt.wantParam {
+				t.Errorf("expected user-id %q, got %q", tt.wantParam, pathParams["user-id"])
+			}
+		})
+	}
+}
+
+func TestNewServeMuxRouterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   string
+		wantErr string
+	}{
+		{
+			name:    "Partial Segment Parameter",
+			wantErr: `path "/files/{name}.json"`,
+			paths: `
+  /files/{name}.json:
+    get:
+      parameters:
+        - {name: name, in: path, required: true, schema: {type: string}}
+      responses:
+        '200': {description: OK}`,
+		},
+		{
+			name:    "Conflicting Patterns",
+			wantErr: `path "/a/{b}" conflicts with path "/{a}/b"`,
+			paths: `
+  /{a}/b:
+    get:
+      parameters:
+        - {name: a, in: path, required: true, schema: {type: string}}
+      responses:
+        '200': {description: OK}
+  /a/{b}:
+    get:
+      parameters:
+        - {name: b, in: path, required: true, schema: {type: string}}
+      responses:
+        '200': {description: OK}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewFromBytes([]byte(`
+openapi: 3.0.0
+info: {title: Test API, version: 1.0.0}
+paths:`+tt.paths), WithStdlibRouting())
+
+			// Assert
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %s, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStdlibRoutingMiddleware(t *testing.T) {
+	// Arrange
+	v, err := NewFromBytes([]byte(testServeMuxSpec), WithStdlibRouting())
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		op, _ := OperationFromContext(r.Context())
+		w.Write([]byte(op.ID + ":" + r.PathValue("id")))
+	})
+	rec := httptest.NewRecorder()
+
+	// Act
+	v.Middleware(mux).ServeHTTP(rec, httptest.NewRequest("GET", "/v1/users/7", nil))
+
+	// Assert
+	if rec.Body.String() != "getUser:7" {
+		t.Errorf("expected getUser:7, got %q", rec.Body.String())
+	}
+}
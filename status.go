@@ -0,0 +1,121 @@
+package openapi_validator
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// ErrForbidden can be returned (or wrapped) by authentication code to signal that credentials were
+// valid but insufficient, e.g. missing scopes. Failed security requirements then map to
+// 403 Forbidden instead of 401 Unauthorized.
+var ErrForbidden = errors.New("forbidden")
+
+// StatusMapper is a function type used to derive the HTTP status code of a validation error.
+type StatusMapper func(err error) int
+
+// HTTPError is a validation error annotated with the HTTP status code it should be reported with.
+// The middleware wraps errors in an HTTPError before handing them to the ErrorEncoder.
+type HTTPError struct {
+	Status int
+	Err    error
+}
+
+// Error implements the error interface for HTTPError.
+func (e *HTTPError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying validation error.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status code for err. It honors the status carried by an HTTPError
+// and falls back to DefaultStatusMapper otherwise.
+func StatusCode(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.Status != 0 {
+		return httpErr.Status
+	}
+	return DefaultStatusMapper(err)
+}
+
+// DefaultStatusMapper is the built-in StatusMapper. It returns
+// 401 Unauthorized (or 403 Forbidden when ErrForbidden is reported) for failed security requirements,
+// 413 Request Entity Too Large for bodies exceeding an http.MaxBytesReader limit,
+// 415 Unsupported Media Type for undeclared request content types,
+// 405 Method Not Allowed and 404 Not Found for unmatched routes,
+// and 400 Bad Request otherwise.
+func DefaultStatusMapper(err error) int {
+	var securityErr *openapi3filter.SecurityRequirementsError
+	if errors.As(err, &securityErr) {
+		if errors.Is(err, ErrForbidden) {
+			return http.StatusForbidden
+		}
+		return http.StatusUnauthorized
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	if isUnsupportedMediaType(err) {
+		return http.StatusUnsupportedMediaType
+	}
+
+	if isMethodNotAllowed(err) {
+		return http.StatusMethodNotAllowed
+	}
+
+	var routeErr *routers.RouteError
+	if errors.As(err, &routeErr) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusBadRequest
+}
+
+// isUnsupportedMediaType reports whether err was caused by a request content type the operation does not declare.
+// kin-openapi only exposes this through the error reason.
+func isUnsupportedMediaType(err error) bool {
+	return matchError(err, func(err error) bool {
+		requestErr, ok := err.(*openapi3filter.RequestError)
+		return ok && requestErr.RequestBody != nil &&
+			strings.HasPrefix(requestErr.Reason, "header Content-Type has unexpected value")
+	})
+}
+
+// matchError reports whether match returns true for err or any error it wraps.
+// Unlike errors.As, it looks past the first error of a given type inside an openapi3.MultiError.
+func matchError(err error, match func(error) bool) bool {
+	if err == nil {
+		return false
+	}
+	if match(err) {
+		return true
+	}
+
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, inner := range e {
+			if matchError(inner, match) {
+				return true
+			}
+		}
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			if matchError(inner, match) {
+				return true
+			}
+		}
+	case interface{ Unwrap() error }:
+		return matchError(e.Unwrap(), match)
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package openapi_validator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+func TestDefaultStatusMapper(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"Generic Error", errors.New("boom"), http.StatusBadRequest},
+		{"Unauthenticated", &openapi3filter.SecurityRequirementsError{Errors: []error{errors.New("missing token")}}, http.StatusUnauthorized},
+		{"Forbidden", &openapi3filter.SecurityRequirementsError{Errors: []error{fmt.Errorf("missing scope: %w", ErrForbidden)}}, http.StatusForbidden},
+		{"Body Too Large", &openapi3filter.RequestError{Reason: "reading failed", Err: &http.MaxBytesError{Limit: 10}}, http.StatusRequestEntityTooLarge},
+		{"Unsupported Media Type", openapi3.MultiError{
+			&openapi3filter.RequestError{Parameter: &openapi3.Parameter{Name: "limit", In: "query"}, Reason: "invalid"},
+			&openapi3filter.RequestError{RequestBody: &openapi3.RequestBody{}, Reason: `header Content-Type has unexpected value "text/plain"`},
+		}, http.StatusUnsupportedMediaType},
+		{"Method Not Allowed", routers.ErrMethodNotAllowed, http.StatusMethodNotAllowed},
+		{"Path Not Found", routers.ErrPathNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange & Act
+			got := DefaultStatusMapper(tt.err)
+
+			// Assert
+			if got != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	// Arrange
+	err := &HTTPError{Status: http.StatusTeapot, Err: errors.New("boom")}
+
+	// Act
+	got := StatusCode(err)
+
+	// Assert
+	if got != http.StatusTeapot {
+		t.Errorf("expected status 418, got %d", got)
+	}
+}
+
+func TestValidator_Middleware_StatusCodes(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("Unsupported Media Type", func(t *testing.T) {
+		// Arrange
+		v, _ := NewFromBytes([]byte(testSpec))
+		req := httptest.NewRequest("POST", "/test", strings.NewReader("name=test"))
+		req.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+
+		// Act
+		v.Middleware(next).ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnsupportedMediaType {
+			t.Errorf("expected status 415, got %d", w.Code)
+		}
+	})
+
+	t.Run("Body Too Large", func(t *testing.T) {
+		// Arrange
+		v, _ := NewFromBytes([]byte(testSpec))
+		req := httptest.NewRequest("POST", "/test", nil)
+		req.Body = http.MaxBytesReader(nil, io.NopCloser(strings.NewReader(`{"name":"test"}`)), 4)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		v.Middleware(next).ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected status 413, got %d", w.Code)
+		}
+	})
+
+	t.Run("Custom Status Mapper", func(t *testing.T) {
+		// Arrange
+		v, _ := NewFromBytes([]byte(testSpec), WithStatusMapper(func(err error) int {
+			return http.StatusUnprocessableEntity
+		}))
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(`{"wrong":"field"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		v.Middleware(next).ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422, got %d", w.Code)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package openapi_validator
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// eventStreamValidator validates the data: payloads of a text/event-stream response against the
+// schema declared for the stream, one event at a time, as the handler writes them.
+// Payloads are decoded as JSON unless the schema is a plain string.
+type eventStreamValidator struct {
+	schema *openapi3.Schema
+	report func(error)
+	line   []byte
+	data   [][]byte
+}
+
+// newEventStreamValidator returns a validator for the text/event-stream response of route with the
+// given status, or nil if the spec declares no schema for it.
+func newEventStreamValidator(route *routers.Route, status int, report func(error)) *eventStreamValidator {
+	if route == nil || route.Operation == nil || route.Operation.Responses == nil {
+		return nil
+	}
+
+	response := route.Operation.Responses.Status(status)
+	if response == nil {
+		response = route.Operation.Responses.Default()
+	}
+	if response == nil || response.Value == nil {
+		return nil
+	}
+
+	mediaType := response.Value.Content.Get("text/event-stream")
+	if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return nil
+	}
+
+	return &eventStreamValidator{
+		schema: mediaType.Schema.Value,
+		report: report,
+	}
+}
+
+// Write consumes a chunk of the stream, validating every event it completes.
+func (ev *eventStreamValidator) Write(b []byte) (int, error) {
+	if ev == nil {
+		return len(b), nil
+	}
+
+	ev.line = append(ev.line, b...)
+	for {
+		i := bytes.IndexByte(ev.line, '\n')
+		if i < 0 {
+			break
+		}
+		ev.processLine(bytes.TrimSuffix(ev.line[:i], []byte("\r")))
+		ev.line = ev.line[i+1:]
+	}
+	return len(b), nil
+}
+
+// processLine handles a single line of the stream; an empty line dispatches the pending event.
+func (ev *eventStreamValidator) processLine(line []byte) {
+	if len(line) == 0 {
+		if len(ev.data) > 0 {
+			ev.validate(bytes.Join(ev.data, []byte("\n")))
+			ev.data = nil
+		}
+		return
+	}
+
+	if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+		value = bytes.TrimPrefix(value, []byte(" "))
+		ev.data = append(ev.data, append([]byte(nil), value...))
+	}
+}
+
+// validate checks the data of one event against the schema and reports any violation.
+func (ev *eventStreamValidator) validate(data []byte) {
+	var value any = string(data)
+	if ev.schema.Type == nil || !ev.schema.Type.Is(openapi3.TypeString) {
+		if err := json.Unmarshal(data, &value); err != nil {
+			ev.report(&openapi3filter.ResponseError{
+				Reason: "event data is not valid JSON",
+				Err:    err,
+			})
+			return
+		}
+	}
+
+	if err := ev.schema.VisitJSON(value, openapi3.MultiErrors()); err != nil {
+		ev.report(&openapi3filter.ResponseError{
+			Reason: "event data doesn't match schema",
+			Err:    err,
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package openapi_validator
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+func TestEventStreamValidator(t *testing.T) {
+	v, err := NewFromBytes([]byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /events:
+    get:
+      responses:
+        '200':
+          description: Event stream
+          content:
+            text/event-stream:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id: {type: integer}
+`))
+	if err != nil {
+		t.Fatalf("failed to create validator: %v", err)
+	}
+	route := &routers.Route{Operation: v.Swagger.Paths.Find("/events").Get}
+
+	tests := []struct {
+		name   string
+		chunks []string
+		want   int
+	}{
+		{"Valid Events", []string{"data: {\"id\":1}\n\n", "event: ping\ndata: {\"id\":2}\n\n"}, 0},
+		{"Event Split Across Writes", []string{"data: {\"i", "d\":\"x\"}\r\n", "\r\n"}, 1},
+		{"Multi-Line Data", []string{"data: {\"id\":\ndata: 3}\n\n"}, 0},
+		{"Invalid JSON", []string{"data: nope\n\n"}, 1},
+		{"Incomplete Event Is Not Validated", []string{"data: {}\n"}, 0},
+		{"Comments Are Ignored", []string{": keep-alive\n\n"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var errs []error
+			ev := newEventStreamValidator(route, http.StatusOK, func(err error) {
+				errs = append(errs, err)
+			})
+
+			// Act
+			for _, chunk := range tt.chunks {
+				ev.Write([]byte(chunk))
+			}
+
+			// Assert
+			if len(errs) != tt.want {
+				t.Errorf("expected %d violations, got %v", tt.want, errs)
+			}
+		})
+	}
+
+	t.Run("No Schema", func(t *testing.T) {
+		// Arrange
+		route := &routers.Route{Operation: &openapi3.Operation{Responses: openapi3.NewResponses()}}
+
+		// Act
+		ev := newEventStreamValidator(route, http.StatusOK, nil)
+
+		// Assert
+		if ev != nil {
+			t.Error("expected no validator without a declared schema")
+		}
+	})
+}
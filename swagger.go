@@ -34,7 +34,7 @@ func (v *Validator) SwaggerUIHandler() http.Handler {
 		// Serve the spec file if requested
 		if relPath == "openapi.json" {
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(v.Swagger)
+			json.NewEncoder(w).Encode(v.Spec())
 			return
 		}
 
@@ -2,119 +2,204 @@ package openapi_validator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
+	"net/url"
+	"os"
+	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
-	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/getkin/kin-openapi/routers"
 )
 
 // Validator is the core component that manages OpenAPI validation and Swagger UI.
 type Validator struct {
 	// Options holds the configuration for the validator.
 	Options *Options
-	// Swagger is the parsed OpenAPI 3 specification.
+	// Swagger is the parsed OpenAPI 3 specification the validator was created with.
+	// After a Reload, use Spec to get the document currently in use.
 	Swagger *openapi3.T
+
+	source   specSource
+	state    atomic.Pointer[specState]
+	reloadMu sync.Mutex
+	// customRouter records that the router was supplied through WithRouter rather than built from the spec.
+	customRouter bool
 }
 
 // New creates a new Validator instance from an OpenAPI spec file and optional configuration.
-// It parses and validates the spec, and initializes the router.
+// It parses and validates the spec, resolving external $refs to local files relative to the
+// file (see NewLoader), and initializes the router.
 func New(specPath string, opts ...Option) (*Validator, error) {
-	ctx := context.Background()
+	return newValidator(specSource{
+		load: func(options *Options) (*openapi3.T, error) {
+			return newLoader(options, openapi3.ReadFromFile).LoadFromFile(specPath)
+		},
+		stat: func() (fs.FileInfo, error) {
+			return os.Stat(specPath)
+		},
+	}, opts...)
+}
+
+// NewFromBytes creates a new Validator instance from an in-memory OpenAPI spec (JSON or YAML).
+// It is useful for specs embedded with go:embed. External $refs are not allowed since there is
+// no location to resolve them against; use NewFromFS for multi-file specs.
+func NewFromBytes(data []byte, opts ...Option) (*Validator, error) {
+	return newValidator(specSource{
+		load: func(*Options) (*openapi3.T, error) {
+			return openapi3.NewLoader().LoadFromData(data)
+		},
+	}, opts...)
+}
+
+// NewFromReader creates a new Validator instance from an OpenAPI spec read from r.
+// The reader is consumed entirely; the same restrictions as NewFromBytes apply.
+func NewFromReader(r io.Reader, opts ...Option) (*Validator, error) {
+	if r == nil {
+		return nil, errors.New("failed to load spec: nil reader")
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	return NewFromBytes(data, opts...)
+}
+
+// NewFromFS creates a new Validator instance from the OpenAPI spec located at specPath within fsys.
+// Relative external $refs are resolved against fsys, so a spec split across several files can be
+// shipped inside the binary with go:embed. Remote (http/https) $refs are only fetched over the
+// network with WithRemoteRefs.
+func NewFromFS(fsys fs.FS, specPath string, opts ...Option) (*Validator, error) {
+	return newValidator(specSource{
+		load: func(options *Options) (*openapi3.T, error) {
+			return newLoader(options, readFromFS(fsys)).LoadFromFile(specPath)
+		},
+		stat: func() (fs.FileInfo, error) {
+			return fs.Stat(fsys, path.Clean(strings.TrimPrefix(specPath, "/")))
+		},
+	}, opts...)
+}
+
+// NewLoader returns an openapi3.Loader configured the way New loads specs: external $refs are
+// resolved relative to the file referencing them, and remote (http/https) ones are only fetched
+// with WithRemoteRefs. Tools loading specs on their own use it to accept the same documents.
+func NewLoader(opts ...Option) *openapi3.Loader {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return newLoader(options, openapi3.ReadFromFile)
+}
+
+// newLoader returns a loader reading local references with local, and remote ones over HTTP
+// when options allow it. Files are read without a cache so that Reload picks up changes.
+func newLoader(options *Options, local openapi3.ReadFromURIFunc) *openapi3.Loader {
+	remote := rejectRemoteRefs
+	if options.RemoteRefs {
+		remote = openapi3.ReadFromHTTP(http.DefaultClient)
+	}
+
 	loader := openapi3.NewLoader()
-	swagger, err := loader.LoadFromFile(specPath)
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = openapi3.ReadFromURIs(local, remote)
+	return loader
+}
+
+// rejectRemoteRefs fails remote references, which are only fetched with WithRemoteRefs.
+func rejectRemoteRefs(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if location.Host == "" {
+		return nil, openapi3.ErrURINotSupported
+	}
+	return nil, fmt.Errorf("remote $ref %s: fetching remote references is disabled", location)
+}
+
+// readFromFS returns an openapi3.ReadFromURIFunc that reads local references from fsys.
+func readFromFS(fsys fs.FS) openapi3.ReadFromURIFunc {
+	return func(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Host != "" || (location.Scheme != "" && location.Scheme != "file") {
+			return nil, openapi3.ErrURINotSupported
+		}
+		// fs.FS only accepts unrooted, cleaned, slash-separated paths.
+		name := path.Clean(strings.TrimPrefix(location.Path, "/"))
+		return fs.ReadFile(fsys, name)
+	}
+}
+
+// newValidator loads and validates the spec from source, applies the options and initializes the router.
+func newValidator(source specSource, opts ...Option) (*Validator, error) {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	swagger, err := source.load(options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load spec: %w", err)
 	}
 
+	ctx := context.Background()
 	if err := swagger.Validate(ctx); err != nil {
 		return nil, fmt.Errorf("invalid spec: %w", err)
 	}
 
-	options := DefaultOptions()
-	for _, opt := range opts {
-		opt(options)
+	v := &Validator{
+		Options:      options,
+		Swagger:      swagger,
+		source:       source,
+		customRouter: options.Router != nil,
 	}
 
-	// Default to gorillamux if no router provided
+	// Default to gorillamux (or the configured factory) if no router provided
 	if options.Router == nil {
-		router, err := gorillamux.NewRouter(swagger)
+		router, err := v.newRouter(swagger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create default router: %w", err)
 		}
 		options.Router = router
 	}
 
-	return &Validator{
-		Options: options,
-		Swagger: swagger,
-	}, nil
+	v.state.Store(&specState{swagger: swagger})
+	return v, nil
 }
 
 // Middleware returns an http.Handler that validates incoming requests and/or outgoing responses.
 func (v *Validator) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Skip validation for Swagger UI
-		if strings.HasPrefix(r.URL.Path, v.Options.SwaggerUIPath) {
+		if v.isSwaggerUIRequest(r) {
 			next.ServeHTTP(w, r)
 			return
 		}
 
 		// Find route
-		route, pathParams, err := v.Options.Router.FindRoute(r)
+		route, pathParams, err := v.router().FindRoute(r)
 		if err != nil {
-			// If route not found, we can decide to either block or pass through.
-			// express-openapi-validator usually lets it pass if not explicitly defined?
-			// but for a strict validator, it's better to log or return error.
-			// Let's pass through for now, as not all routes might be in OpenAPI spec.
-			next.ServeHTTP(w, r)
+			// Not all routes might be in the OpenAPI spec, the policy decides whether they get through.
+			if v.handleUnmatchedRoute(w, r, err) {
+				next.ServeHTTP(w, r)
+			}
 			return
 		}
 
-		// Validate Request
-		if v.Options.ValidateRequests {
-			requestValidationInput := &openapi3filter.RequestValidationInput{
-				Request:    r,
-				PathParams: pathParams,
-				Route:      route,
-			}
-			if err := openapi3filter.ValidateRequest(context.Background(), requestValidationInput); err != nil {
-				v.Options.ErrorEncoder(w, r, err)
-				return
-			}
+		// Authenticate, apply defaults and validate the request
+		r, err = v.ValidateRequest(r, route, pathParams)
+		if err != nil {
+			v.Options.ErrorEncoder(w, r, err)
+			return
 		}
 
 		// Response validation
 		if v.Options.ValidateResponses {
-			rw := &responseWriter{
-				ResponseWriter: w,
-				header:         w.Header(),
-			}
-			next.ServeHTTP(rw, r)
-
-			// After handler. Check if we should validate
-			responseValidationInput := &openapi3filter.ResponseValidationInput{
-				RequestValidationInput: &openapi3filter.RequestValidationInput{
-					Request:    r,
-					PathParams: pathParams,
-					Route:      route,
-				},
-				Status: rw.status,
-				Header: rw.header,
-			}
-
-			if rw.body != nil {
-				responseValidationInput.SetBodyBytes(rw.body)
-			}
-
-			if err := openapi3filter.ValidateResponse(context.Background(), responseValidationInput); err != nil {
-				// NOTE: We already sent the response to the user.
-				// Response validation is mostly for development/logging.
-				// We could log it here.
-				fmt.Printf("Response validation error: %v\n", err)
-			}
+			v.ServeResponse(w, r, next, route, pathParams)
 			return
 		}
 
@@ -122,22 +207,72 @@ func (v *Validator) Middleware(next http.Handler) http.Handler {
 	})
 }
 
-type responseWriter struct {
-	http.ResponseWriter
-	status int
-	body   []byte
-	header http.Header
+// isSwaggerUIRequest reports whether r is for the Swagger UI or the spec it serves, which are
+// not part of the spec and so are never validated.
+func (v *Validator) isSwaggerUIRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, v.Options.SwaggerUIPath)
 }
 
-func (rw *responseWriter) WriteHeader(status int) {
-	rw.status = status
-	rw.ResponseWriter.WriteHeader(status)
+// encodeError annotates err with its HTTP status code and hands it to the ErrorEncoder.
+func (v *Validator) encodeError(w http.ResponseWriter, r *http.Request, err error) {
+	v.Options.ErrorEncoder(w, r, v.httpError(err))
 }
 
-func (rw *responseWriter) Write(b []byte) (int, error) {
-	if rw.status == 0 {
-		rw.status = http.StatusOK
+// httpError wraps err with the status code derived by the StatusMapper.
+func (v *Validator) httpError(err error) *HTTPError {
+	mapper := v.Options.StatusMapper
+	if mapper == nil {
+		mapper = DefaultStatusMapper
 	}
-	rw.body = append(rw.body, b...)
-	return rw.ResponseWriter.Write(b)
+	return &HTTPError{Status: mapper(err), Err: err}
+}
+
+// ValidateRequest authenticates and validates r against an already matched route, applying
+// defaults when enabled. It returns the request carrying the operation, principals and decoded
+// parameters in its context. Failures are returned as an *HTTPError with the mapped status.
+//
+// Middleware calls it for every matched request; adapters for routers that match requests
+// themselves can call it directly, together with OperationRoute.
+func (v *Validator) ValidateRequest(r *http.Request, route *routers.Route, pathParams map[string]string) (*http.Request, error) {
+	r = r.WithContext(withRoute(r.Context(), route, pathParams))
+
+	// Authenticate against the operation's security requirements
+	if len(v.Options.Authenticators) > 0 {
+		principals, err := v.authenticate(r, route)
+		if err != nil {
+			return r, v.httpError(err)
+		}
+		if principals != nil {
+			r = r.WithContext(context.WithValue(r.Context(), principalsKey, principals))
+		}
+	}
+
+	// Inject schema defaults and coercions so the handler does not have to duplicate them
+	if v.Options.ApplyDefaults {
+		if err := applyDefaults(r, route); err != nil {
+			return r, v.httpError(err)
+		}
+	}
+
+	// Validate Request
+	if v.Options.ValidateRequests {
+		requestValidationInput := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			// Report every failure so clients can highlight all offending fields at once.
+			// Defaults are only written to the request when ApplyDefaults is set.
+			Options: &openapi3filter.Options{MultiError: true, SkipSettingDefaults: true},
+		}
+		if len(v.Options.Authenticators) > 0 {
+			// Security requirements were already enforced above.
+			requestValidationInput.Options.AuthenticationFunc = openapi3filter.NoopAuthenticationFunc
+		}
+		if err := openapi3filter.ValidateRequest(context.Background(), requestValidationInput); err != nil {
+			return r, v.httpError(err)
+		}
+	}
+
+	// Expose the decoded parameters to the handler
+	return r.WithContext(context.WithValue(r.Context(), paramsKey, decodeParams(r, route, pathParams))), nil
 }
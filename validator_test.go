@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 const testSpec = `
@@ -122,6 +125,218 @@ func TestNew(t *testing.T) {
 			t.Error("expected error for invalid spec")
 		}
 	})
+
+	t.Run("Resolves Parent Directory Refs", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		os.Mkdir(filepath.Join(dir, "api"), 0755)
+		os.Mkdir(filepath.Join(dir, "common"), 0755)
+		os.WriteFile(filepath.Join(dir, "common", "input.yaml"), []byte("type: object\nrequired: [name]\n"), 0644)
+		os.WriteFile(filepath.Join(dir, "api", "openapi.yaml"), []byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /test:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '../common/input.yaml'
+      responses:
+        '200':
+          description: OK
+`), 0644)
+
+		// Act
+		v, err := New(filepath.Join(dir, "api", "openapi.yaml"))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("failed to create validator: %v", err)
+		}
+		schema := v.Swagger.Paths.Find("/test").Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+		if len(schema.Required) != 1 {
+			t.Errorf("expected the referenced schema to be loaded, got %+v", schema)
+		}
+	})
+
+	t.Run("Remote Refs", func(t *testing.T) {
+		var fetched int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fetched++
+			w.Write([]byte("type: object\nrequired: [name]\n"))
+		}))
+		defer server.Close()
+		specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+		os.WriteFile(specPath, []byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /test:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '`+server.URL+`/input.yaml'
+      responses:
+        '200':
+          description: OK
+`), 0644)
+
+		t.Run("Not Fetched By Default", func(t *testing.T) {
+			// Arrange
+			fetched = 0
+
+			// Act
+			_, err := New(specPath)
+
+			// Assert
+			if err == nil || !strings.Contains(err.Error(), "fetching remote references is disabled") {
+				t.Errorf("expected the remote ref to be rejected, got %v", err)
+			}
+			if fetched != 0 {
+				t.Error("expected no network request")
+			}
+		})
+
+		t.Run("Fetched With WithRemoteRefs", func(t *testing.T) {
+			// Arrange
+			fetched = 0
+
+			// Act
+			_, err := New(specPath, WithRemoteRefs())
+
+			// Assert
+			if err != nil {
+				t.Fatalf("failed to create validator: %v", err)
+			}
+			if fetched != 1 {
+				t.Errorf("expected the remote ref to be fetched once, got %d", fetched)
+			}
+		})
+	})
+}
+
+func TestNewFromBytes(t *testing.T) {
+	t.Run("Valid Spec", func(t *testing.T) {
+		// Arrange & Act
+		v, err := NewFromBytes([]byte(testSpec))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("failed to create validator: %v", err)
+		}
+
+		if v.Swagger.Paths.Find("/test") == nil {
+			t.Error("expected /test path to be loaded")
+		}
+	})
+
+	t.Run("Invalid Spec Content", func(t *testing.T) {
+		// Arrange & Act
+		_, err := NewFromBytes([]byte("invalid yaml content"))
+
+		// Assert
+		if err == nil {
+			t.Error("expected error for invalid spec")
+		}
+	})
+}
+
+func TestNewFromReader(t *testing.T) {
+	t.Run("Valid Spec", func(t *testing.T) {
+		// Arrange & Act
+		v, err := NewFromReader(strings.NewReader(testSpec), WithSwaggerUIPath("/api/docs"))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("failed to create validator: %v", err)
+		}
+
+		if v.Options.SwaggerUIPath != "/api/docs" {
+			t.Errorf("expected options to be applied, got SwaggerUIPath %s", v.Options.SwaggerUIPath)
+		}
+	})
+
+	t.Run("Nil Reader", func(t *testing.T) {
+		// Arrange & Act
+		_, err := NewFromReader(nil)
+
+		// Assert
+		if err == nil {
+			t.Error("expected error for nil reader")
+		}
+	})
+}
+
+func TestNewFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"specs/openapi.yaml": {Data: []byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /test:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: './schemas/input.yaml'
+      responses:
+        '200':
+          description: OK
+`)},
+		"specs/schemas/input.yaml": {Data: []byte(`
+type: object
+required: [name]
+properties:
+  name: {type: string}
+`)},
+	}
+
+	t.Run("Resolves Relative Refs", func(t *testing.T) {
+		// Arrange
+		v, err := NewFromFS(fsys, "specs/openapi.yaml")
+		if err != nil {
+			t.Fatalf("failed to create validator: %v", err)
+		}
+
+		handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"wrong":"field"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(w, req)
+
+		// Assert
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 from referenced schema, got %d", w.Code)
+		}
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		// Arrange & Act
+		_, err := NewFromFS(fsys, "specs/missing.yaml")
+
+		// Assert
+		if err == nil {
+			t.Error("expected error for missing file")
+		}
+	})
 }
 
 func TestValidator_Middleware_ResponseValidation(t *testing.T) {